@@ -4,9 +4,13 @@ package controllers
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/suidevv/tableye-api/initializers"
 	"github.com/suidevv/tableye-api/models"
 	"gorm.io/gorm"
 )
@@ -53,12 +57,633 @@ func (ac *AdminController) AssignAdminRole(ctx *gin.Context) {
 		return
 	}
 
+	before := user
 	user.Role = "admin"
-	ac.DB.Save(&user)
+	err = ac.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&user).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, ctx, models.AuditActionUpdate, "user", user.ID, before, user)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to update user role"})
+		return
+	}
 
 	ctx.JSON(http.StatusOK, gin.H{"status": "success", "message": "User role updated to admin"})
 }
 
+// RecalculateGameSummaryTotals godoc
+// @Summary Rebuild game summary totals
+// @Description Recomputes total pot, highest bet and rounds played of every game summary from its transactions
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/game-summaries/recalculate-totals [post]
+func (ac *AdminController) RecalculateGameSummaryTotals(ctx *gin.Context) {
+	var gameSummaries []models.GameSummary
+	recalculated := 0
+
+	result := ac.DB.Select("id").FindInBatches(&gameSummaries, 100, func(_ *gorm.DB, _ int) error {
+		return ac.DB.Transaction(func(tx *gorm.DB) error {
+			for _, gameSummary := range gameSummaries {
+				if err := recalculateGameSummaryTotals(tx, gameSummary.ID); err != nil {
+					return err
+				}
+			}
+			recalculated += len(gameSummaries)
+			return nil
+		})
+	})
+	if result.Error != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to recalculate game summary totals"})
+		return
+	}
+	// Totals are derived data, so one event for the whole rebuild is enough
+	if err := recordAudit(ac.DB, ctx, models.AuditActionUpdate, "game_summary_totals", "*", nil, gin.H{"recalculated": recalculated}); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to record recalculation"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "results": recalculated})
+}
+
+// GrantCasinoOwnership godoc
+// @Summary Grant ownership of a casino to a user
+// @Description Links a user to a casino as its owner and gives them the casino_owner role. Admins and users with a dealer profile cannot be made owners.
+// @Tags admin
+// @Produce json
+// @Param casinoId path string true "Casino ID"
+// @Param userId path string true "User ID"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/casinos/{casinoId}/owners/{userId} [post]
+func (ac *AdminController) GrantCasinoOwnership(ctx *gin.Context) {
+	casino, user, ok := ac.findCasinoAndUser(ctx)
+	if !ok {
+		return
+	}
+
+	if user.Role == "admin" {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Admins already have access to every casino"})
+		return
+	}
+
+	var dealerCount int64
+	if err := ac.DB.Model(&models.Dealer{}).Where("user_id = ?", user.ID).Count(&dealerCount).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to check dealer profile"})
+		return
+	}
+	if dealerCount > 0 {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "User has a dealer profile"})
+		return
+	}
+
+	var inserted int64
+	err := ac.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec("INSERT INTO casino_owners (casino_id, user_id) VALUES (?, ?) ON CONFLICT DO NOTHING", casino.ID, user.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		inserted = result.RowsAffected
+		if inserted == 0 {
+			return nil
+		}
+		if err := recordAudit(tx, ctx, models.AuditActionCreate, "casino_owner", casino.ID.String()+":"+user.ID.String(),
+			nil, gin.H{"casino_id": casino.ID, "user_id": user.ID}); err != nil {
+			return err
+		}
+
+		if user.Role != "casino_owner" {
+			before := user
+			if err := tx.Model(&user).Update("role", "casino_owner").Error; err != nil {
+				return err
+			}
+			return recordAudit(tx, ctx, models.AuditActionUpdate, "user", user.ID, before, user)
+		}
+		return nil
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to grant casino ownership"})
+		return
+	}
+	if inserted == 0 {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "User already owns this casino"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"status": "success", "data": gin.H{"casino_id": casino.ID, "user_id": user.ID}})
+}
+
+// RevokeCasinoOwnership godoc
+// @Summary Revoke ownership of a casino from a user
+// @Description Unlinks a casino owner from a casino. The user keeps the casino_owner role for any other casinos they own.
+// @Tags admin
+// @Produce json
+// @Param casinoId path string true "Casino ID"
+// @Param userId path string true "User ID"
+// @Security BearerAuth
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/casinos/{casinoId}/owners/{userId} [delete]
+func (ac *AdminController) RevokeCasinoOwnership(ctx *gin.Context) {
+	casino, user, ok := ac.findCasinoAndUser(ctx)
+	if !ok {
+		return
+	}
+
+	var deleted int64
+	err := ac.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec("DELETE FROM casino_owners WHERE casino_id = ? AND user_id = ?", casino.ID, user.ID)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		deleted = result.RowsAffected
+		return recordAudit(tx, ctx, models.AuditActionDelete, "casino_owner", casino.ID.String()+":"+user.ID.String(),
+			gin.H{"casino_id": casino.ID, "user_id": user.ID}, nil)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to revoke casino ownership"})
+		return
+	}
+	if deleted == 0 {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "User does not own this casino"})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// FindCasinoOwners godoc
+// @Summary List the owners of a casino
+// @Description Returns the users that own a casino
+// @Tags admin
+// @Produce json
+// @Param casinoId path string true "Casino ID"
+// @Security BearerAuth
+// @Success 200 {array} models.UserResponse
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/casinos/{casinoId}/owners [get]
+func (ac *AdminController) FindCasinoOwners(ctx *gin.Context) {
+	var casino models.Casino
+	if err := ac.DB.First(&casino, "id = ?", ctx.Param("casinoId")).Error; err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No casino with that ID exists"})
+		return
+	}
+
+	var users []models.User
+	if err := ac.DB.Joins("JOIN casino_owners co ON co.user_id = users.id").
+		Where("co.casino_id = ?", casino.ID).
+		Order("users.name").
+		Find(&users).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch casino owners"})
+		return
+	}
+
+	owners := make([]models.UserResponse, len(users))
+	for i, user := range users {
+		owners[i] = models.UserResponse{
+			ID:        user.ID,
+			Name:      user.Name,
+			Email:     user.Email,
+			Role:      user.Role,
+			Provider:  user.Provider,
+			CreatedAt: user.CreatedAt,
+			UpdatedAt: user.UpdatedAt,
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "results": len(owners), "data": owners})
+}
+
+// UnlockAccount godoc
+// @Summary Unlock an account
+// @Description Clears the failed sign-in attempts of a user so they can log in again straight away. Blocks on client IPs are left alone.
+// @Tags admin
+// @Produce json
+// @Param userId path string true "User ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/users/{userId}/unlock [post]
+func (ac *AdminController) UnlockAccount(ctx *gin.Context) {
+	currentUser := ctx.MustGet("currentUser").(models.User)
+
+	userID, err := uuid.Parse(ctx.Param("userId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid user ID"})
+		return
+	}
+
+	var user models.User
+	if err := ac.DB.First(&user, "id = ?", userID).Error; err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "User not found"})
+		return
+	}
+
+	config, _ := initializers.LoadConfig(".")
+	err = ac.DB.Transaction(func(tx *gorm.DB) error {
+		event := models.SecurityEvent{
+			Type:      models.SecurityEventAccountUnlocked,
+			UserID:    &user.ID,
+			Email:     user.Email,
+			ClientIP:  ctx.ClientIP(),
+			ActorID:   &currentUser.ID,
+			CreatedAt: time.Now(),
+		}
+		if err := tx.Create(&event).Error; err != nil {
+			return err
+		}
+		if err := recordAudit(tx, ctx, models.AuditActionDelete, "account_lock", user.ID, gin.H{"email": user.Email}, nil); err != nil {
+			return err
+		}
+		// Reset last, so a failed reset leaves no record of an unlock
+		return config.AccountLimiter().Reset(accountLimitKey(user.Email))
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to unlock account"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "message": "Account unlocked"})
+}
+
+// FindSecurityEvents godoc
+// @Summary List security events
+// @Description Returns lockouts and unlocks, newest first, with pagination and optional filters
+// @Tags admin
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Param type query string false "Event type" Enums(account_locked, ip_blocked, account_unlocked)
+// @Param user_id query string false "User ID to filter by"
+// @Param email query string false "Email address to filter by"
+// @Param from query string false "Only events at or after this date"
+// @Param to query string false "Only events before this date"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/security-events [get]
+func (ac *AdminController) FindSecurityEvents(ctx *gin.Context) {
+	intPage, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	intLimit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	offset := (intPage - 1) * intLimit
+
+	from, to, err := parseTimeRange(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	query := ac.DB.Model(&models.SecurityEvent{})
+
+	if eventType := ctx.Query("type"); eventType != "" {
+		query = query.Where("type = ?", eventType)
+	}
+
+	if userID := ctx.Query("user_id"); userID != "" {
+		if _, err := uuid.Parse(userID); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid user ID"})
+			return
+		}
+		query = query.Where("user_id = ?", userID)
+	}
+
+	if email := ctx.Query("email"); email != "" {
+		query = query.Where("email = ?", strings.ToLower(email))
+	}
+
+	if from != nil {
+		query = query.Where("created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("created_at < ?", *to)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to count security events"})
+		return
+	}
+
+	var events []models.SecurityEvent
+	if err := query.Order("created_at DESC").Limit(intLimit).Offset(offset).Find(&events).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch security events"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"results": len(events),
+		"total":   total,
+		"page":    intPage,
+		"limit":   intLimit,
+		"data":    events,
+	})
+}
+
+// FindAuditEvents godoc
+// @Summary List audit events
+// @Description Returns the changes made through the API, newest first, with pagination and optional filters
+// @Tags admin
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Param actor_id query string false "User ID of the actor to filter by"
+// @Param action query string false "Action" Enums(create, update, delete)
+// @Param entity_type query string false "Entity type to filter by, such as casino or transaction"
+// @Param entity_id query string false "Entity ID to filter by"
+// @Param request_id query string false "Request ID to filter by"
+// @Param from query string false "Only events at or after this date"
+// @Param to query string false "Only events before this date"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/audit [get]
+func (ac *AdminController) FindAuditEvents(ctx *gin.Context) {
+	intPage, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	intLimit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	offset := (intPage - 1) * intLimit
+
+	from, to, err := parseTimeRange(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	query := ac.DB.Model(&models.AuditEvent{})
+
+	if actorID := ctx.Query("actor_id"); actorID != "" {
+		if _, err := uuid.Parse(actorID); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid actor ID"})
+			return
+		}
+		query = query.Where("actor_id = ?", actorID)
+	}
+
+	if action := ctx.Query("action"); action != "" {
+		query = query.Where("action = ?", action)
+	}
+
+	if entityType := ctx.Query("entity_type"); entityType != "" {
+		query = query.Where("entity_type = ?", entityType)
+	}
+
+	if entityID := ctx.Query("entity_id"); entityID != "" {
+		query = query.Where("entity_id = ?", entityID)
+	}
+
+	if requestID := ctx.Query("request_id"); requestID != "" {
+		query = query.Where("request_id = ?", requestID)
+	}
+
+	if from != nil {
+		query = query.Where("created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("created_at < ?", *to)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to count audit events"})
+		return
+	}
+
+	var events []models.AuditEvent
+	if err := query.Order("sequence DESC").Limit(intLimit).Offset(offset).Find(&events).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch audit events"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"results": len(events),
+		"total":   total,
+		"page":    intPage,
+		"limit":   intLimit,
+		"data":    events,
+	})
+}
+
+// VerifyAuditChain godoc
+// @Summary Verify the audit log
+// @Description Walks the audit hash chain from the first event and reports the sequence of the first event that was changed, removed or inserted out of order
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/audit/verify [get]
+func (ac *AdminController) VerifyAuditChain(ctx *gin.Context) {
+	var (
+		checked  int64
+		sequence int64
+		prevHash string
+		brokenAt *int64
+	)
+
+	for brokenAt == nil {
+		var events []models.AuditEvent
+		if err := ac.DB.Where("sequence > ?", sequence).Order("sequence").Limit(500).Find(&events).Error; err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch audit events"})
+			return
+		}
+		if len(events) == 0 {
+			break
+		}
+
+		for _, event := range events {
+			hash, err := event.ComputeHash()
+			if err != nil || event.Sequence != sequence+1 || event.PrevHash != prevHash || event.Hash != hash {
+				brokenAt = &event.Sequence
+				break
+			}
+			checked++
+			sequence = event.Sequence
+			prevHash = event.Hash
+		}
+	}
+
+	data := gin.H{"valid": brokenAt == nil, "checked": checked}
+	if brokenAt != nil {
+		data["broken_at"] = *brokenAt
+	}
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+}
+
+// SetExchangeRate godoc
+// @Summary Set an exchange rate
+// @Description Sets what one unit of a currency is worth in the reporting currency (EUR) from a date until the next rate of that currency. A rate already set for that date is replaced. Player totals keep the rates they were booked with; the reconcile command brings them in line after a past rate changes.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param rate body models.SetExchangeRateRequest true "Currency, date and rate"
+// @Security BearerAuth
+// @Success 200 {object} models.ExchangeRate
+// @Success 201 {object} models.ExchangeRate
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/exchange-rates [put]
+func (ac *AdminController) SetExchangeRate(ctx *gin.Context) {
+	var payload models.SetExchangeRateRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+	if payload.Currency == models.DefaultCurrency {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Rates are quoted in " + models.DefaultCurrency + ", its own rate is always 1"})
+		return
+	}
+	date, err := time.Parse("2006-01-02", payload.Date)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid date, use YYYY-MM-DD"})
+		return
+	}
+
+	now := time.Now()
+	var rate models.ExchangeRate
+	created := false
+	err = ac.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("currency = ? AND date = ?", payload.Currency, date).Limit(1).Find(&rate)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			created = true
+			rate = models.ExchangeRate{Currency: payload.Currency, Date: date, Rate: payload.Rate, CreatedAt: now, UpdatedAt: now}
+			if err := tx.Create(&rate).Error; err != nil {
+				return err
+			}
+			return recordAudit(tx, ctx, models.AuditActionCreate, "exchange_rate", rate.ID, nil, rate)
+		}
+
+		before := rate
+		if err := tx.Model(&rate).Updates(models.ExchangeRate{Rate: payload.Rate, UpdatedAt: now}).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, ctx, models.AuditActionUpdate, "exchange_rate", rate.ID, before, rate)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to set exchange rate"})
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	ctx.JSON(status, gin.H{"status": "success", "data": rate})
+}
+
+// FindExchangeRates godoc
+// @Summary List exchange rates
+// @Description Returns the exchange rates by currency, newest date first, with pagination and optional filters
+// @Tags admin
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Param currency query string false "Currency to filter by"
+// @Param from query string false "Only rates from this date"
+// @Param to query string false "Only rates up to this date"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/exchange-rates [get]
+func (ac *AdminController) FindExchangeRates(ctx *gin.Context) {
+	intPage, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	intLimit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	offset := (intPage - 1) * intLimit
+
+	from, to, err := parseTimeRange(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	query := ac.DB.Model(&models.ExchangeRate{})
+
+	if currency := ctx.Query("currency"); currency != "" {
+		query = query.Where("currency = ?", strings.ToUpper(currency))
+	}
+
+	if from != nil {
+		query = query.Where("date >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("date < ?", *to)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to count exchange rates"})
+		return
+	}
+
+	var rates []models.ExchangeRate
+	if err := query.Order("currency, date DESC").Limit(intLimit).Offset(offset).Find(&rates).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch exchange rates"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"results": len(rates),
+		"total":   total,
+		"page":    intPage,
+		"limit":   intLimit,
+		"data":    rates,
+	})
+}
+
+func (ac *AdminController) findCasinoAndUser(ctx *gin.Context) (models.Casino, models.User, bool) {
+	var casino models.Casino
+	var user models.User
+
+	userID, err := uuid.Parse(ctx.Param("userId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid user ID"})
+		return casino, user, false
+	}
+
+	if err := ac.DB.First(&casino, "id = ?", ctx.Param("casinoId")).Error; err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No casino with that ID exists"})
+		return casino, user, false
+	}
+
+	if err := ac.DB.First(&user, "id = ?", userID).Error; err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "User not found"})
+		return casino, user, false
+	}
+
+	return casino, user, true
+}
+
 // AdminRoleAssignRequest represents the request body for assigning admin role
 type AdminRoleAssignRequest struct {
 	UserID string `json:"userId" binding:"required"`
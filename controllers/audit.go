@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/suidevv/tableye-api/models"
+	"github.com/suidevv/tableye-api/utils"
+	"gorm.io/gorm"
+)
+
+// auditChainLock is the advisory lock that serialises appends to the audit
+// hash chain.
+const auditChainLock = 0x617564
+
+// recordAudit appends an audit event for a change made by the request, with
+// the fields that differ between before and after. Pass nil before for
+// creates and nil after for deletes. Call it inside the transaction of the
+// change so the event and the change commit together; the chain stays locked
+// until that transaction ends.
+func recordAudit(db *gorm.DB, ctx *gin.Context, action, entityType string, entityID interface{}, before, after interface{}) error {
+	changes, err := utils.JSONDiff(before, after)
+	if err != nil {
+		return fmt.Errorf("audit: diff %s: %w", entityType, err)
+	}
+
+	event := models.AuditEvent{
+		ID:         uuid.New(),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   fmt.Sprint(entityID),
+		Changes:    changes,
+		ClientIP:   ctx.ClientIP(),
+		RequestID:  ctx.GetString("requestID"),
+		CreatedAt:  time.Now().UTC().Truncate(time.Microsecond),
+	}
+	if value, ok := ctx.Get("currentUser"); ok {
+		if user, ok := value.(models.User); ok {
+			event.ActorID = &user.ID
+			event.ActorRole = user.Role
+		}
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", auditChainLock).Error; err != nil {
+			return err
+		}
+
+		var last models.AuditEvent
+		err := tx.Order("sequence DESC").Take(&last).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			event.Sequence = 1
+		case err != nil:
+			return err
+		default:
+			event.Sequence = last.Sequence + 1
+			event.PrevHash = last.Hash
+		}
+
+		if event.Hash, err = event.ComputeHash(); err != nil {
+			return err
+		}
+		return tx.Create(&event).Error
+	})
+}
@@ -3,30 +3,33 @@
 package controllers
 
 import (
-	"fmt"
+	"errors"
+	"log"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
 	"github.com/suidevv/tableye-api/initializers"
+	"github.com/suidevv/tableye-api/middleware"
 	"github.com/suidevv/tableye-api/models"
 	"github.com/suidevv/tableye-api/utils"
 	"gorm.io/gorm"
 )
 
 type AuthController struct {
-	DB *gorm.DB
+	DB     *gorm.DB
+	Tokens *utils.TokenService
 }
 
-func NewAuthController(DB *gorm.DB) AuthController {
-	return AuthController{DB}
+func NewAuthController(DB *gorm.DB, Tokens *utils.TokenService) AuthController {
+	return AuthController{DB, Tokens}
 }
 
 // SignUpUser godoc
 // @Summary Register a new user
-// @Description Register a new user with the provided details
+// @Description Register a new user with the provided details. The user must verify their email address through the mailed link before logging in.
 // @Tags authentication
 // @Accept json
 // @Produce json
@@ -61,22 +64,30 @@ func (ac *AuthController) SignUpUser(ctx *gin.Context) {
 		Email:     strings.ToLower(payload.Email),
 		Password:  hashedPassword,
 		Role:      "dealer", // Default role is now "dealer"
-		Verified:  true,
+		Verified:  false,
 		Provider:  "local",
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
 
-	result := ac.DB.Create(&newUser)
+	err = ac.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&newUser).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, ctx, models.AuditActionCreate, "user", newUser.ID, nil, newUser)
+	})
 
-	if result.Error != nil && strings.Contains(result.Error.Error(), "duplicate key value violates unique") {
+	if err != nil && strings.Contains(err.Error(), "duplicate key value violates unique") {
 		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "User with that email already exists"})
 		return
-	} else if result.Error != nil {
+	} else if err != nil {
 		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Something bad happened"})
 		return
 	}
 
+	config, _ := initializers.LoadConfig(".")
+	sendVerificationMail(ac.DB, config, newUser)
+
 	userResponse := &models.UserResponse{
 		ID:        newUser.ID,
 		Name:      newUser.Name,
@@ -86,7 +97,7 @@ func (ac *AuthController) SignUpUser(ctx *gin.Context) {
 		CreatedAt: newUser.CreatedAt,
 		UpdatedAt: newUser.UpdatedAt,
 	}
-	ctx.JSON(http.StatusCreated, gin.H{"status": "success", "data": gin.H{"user": userResponse}})
+	ctx.JSON(http.StatusCreated, gin.H{"status": "success", "message": "We sent a verification link to " + newUser.Email, "data": gin.H{"user": userResponse}})
 }
 
 // SignInUser godoc
@@ -98,7 +109,11 @@ func (ac *AuthController) SignUpUser(ctx *gin.Context) {
 // @Param request body models.SignInInput true "User login credentials"
 // @Success 200 {object} map[string]interface{} "Login successful"
 // @Success 200 {object} models.SignInResponse
+// @Success 202 {object} models.TwoFactorChallengeResponse "Two-factor code required, complete with /auth/2fa/verify"
 // @Failure 400 {object} map[string]interface{} "Invalid credentials"
+// @Failure 403 {object} map[string]interface{} "Email address not verified"
+// @Failure 429 {object} map[string]interface{} "Too many failed attempts"
+// @Failure 500 {object} map[string]interface{}
 // @Router /auth/login [post]
 func (ac *AuthController) SignInUser(ctx *gin.Context) {
 	var payload *models.SignInInput
@@ -108,28 +123,106 @@ func (ac *AuthController) SignInUser(ctx *gin.Context) {
 		return
 	}
 
+	config, _ := initializers.LoadConfig(".")
+	email := strings.ToLower(payload.Email)
+	clientIP := ctx.ClientIP()
+
+	if wait := signInBlocked(config, email, clientIP); wait > 0 {
+		respondSignInBlocked(ctx, wait)
+		return
+	}
+
 	var user models.User
-	result := ac.DB.First(&user, "email = ?", strings.ToLower(payload.Email))
+	result := ac.DB.First(&user, "email = ?", email)
 	if result.Error != nil {
+		recordFailedSignIn(ac.DB, config, email, clientIP, nil)
 		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid email or Password"})
 		return
 	}
 
 	if err := utils.VerifyPassword(user.Password, payload.Password); err != nil {
+		recordFailedSignIn(ac.DB, config, email, clientIP, &user)
 		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid email or Password"})
 		return
 	}
 
-	config, _ := initializers.LoadConfig(".")
+	// Only the account's count is cleared; the IP may still be guessing others
+	if err := config.AccountLimiter().Reset(accountLimitKey(email)); err != nil {
+		log.Printf("Failed to reset sign-in limit for %s: %v", email, err)
+	}
+
+	if !user.Verified {
+		ctx.JSON(http.StatusForbidden, gin.H{"status": "fail", "message": "Please verify your email address before logging in"})
+		return
+	}
+
+	if user.TwoFactorEnabled {
+		ac.sendTwoFactorChallenge(ctx, config, user)
+		return
+	}
+
+	ac.completeSignIn(ctx, config, user)
+}
+
+// completeSignIn opens a session for user and responds with its tokens and
+// the user's dealer profile and casinos.
+func (ac *AuthController) completeSignIn(ctx *gin.Context, config initializers.Config, user models.User) {
+	// Load the dealer profile and casinos first so a lookup failure does not
+	// pass for a user without assignments
+	var dealer models.Dealer
+	dealerResult := ac.DB.Limit(1).Find(&dealer, "user_id = ?", user.ID)
+	if dealerResult.Error != nil {
+		log.Printf("Failed to fetch dealer profile of %s: %v", user.ID, dealerResult.Error)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch dealer profile"})
+		return
+	}
+	hasDealer := dealerResult.RowsAffected > 0
+
+	var casinos []models.CasinoResponse
+	if hasDealer {
+		var err error
+		casinos, err = findCasinosByJoin(ac.DB, "casino_dealers", "dealer_id", dealer.ID.String())
+		if err != nil {
+			log.Printf("Failed to fetch casinos of dealer %s: %v", dealer.ID, err)
+			ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch assigned casinos"})
+			return
+		}
+	}
+
+	now := time.Now()
+	userAgent := ctx.Request.UserAgent()
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+	session := models.Session{
+		ID:             uuid.New(),
+		UserID:         user.ID,
+		CurrentTokenID: uuid.New(),
+		UserAgent:      userAgent,
+		ClientIP:       ctx.ClientIP(),
+		ExpiresAt:      now.Add(config.RefreshTokenExpiresIn),
+		LastUsedAt:     now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := ac.DB.Create(&session).Error; err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Failed to create session"})
+		return
+	}
 
 	// Generate Tokens
-	access_token, err := utils.CreateToken(config.AccessTokenExpiresIn, user.ID, config.AccessTokenPrivateKey)
+	access_token, err := ac.createAccessToken(config, user, session.ID)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
 		return
 	}
 
-	refresh_token, err := utils.CreateToken(config.RefreshTokenExpiresIn, user.ID, config.RefreshTokenPrivateKey)
+	refresh_token, _, err := ac.Tokens.CreateToken(utils.TokenClaims{
+		Subject:   user.ID.String(),
+		SessionID: session.ID.String(),
+		TokenID:   session.CurrentTokenID.String(),
+		Type:      utils.TokenTypeRefresh,
+	}, config.RefreshTokenExpiresIn)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
 		return
@@ -139,25 +232,6 @@ func (ac *AuthController) SignInUser(ctx *gin.Context) {
 	ctx.SetCookie("refresh_token", refresh_token, config.RefreshTokenMaxAge*60, "/", config.Domain, false, true)
 	ctx.SetCookie("logged_in", "true", config.AccessTokenMaxAge*60, "/", config.Domain, false, false)
 
-	// Fetch associated dealer
-	var dealer models.Dealer
-	dealerResult := ac.DB.First(&dealer, "user_id = ?", user.ID)
-
-	// Fetch associated casino
-	var casino models.Casino
-	var casinoID *string
-	if dealerResult.Error == nil {
-		casinoResult := ac.DB.Table("casino_dealers").
-			Select("casino_id").
-			Where("dealer_id = ?", dealer.ID).
-			Limit(1).
-			Scan(&casinoID)
-
-		if casinoResult.Error == nil && casinoID != nil {
-			ac.DB.First(&casino, "id = ?", *casinoID)
-		}
-	}
-
 	response := models.SignInResponse{
 		AccessToken: access_token,
 		User: models.UserResponse{
@@ -167,7 +241,7 @@ func (ac *AuthController) SignInUser(ctx *gin.Context) {
 		},
 	}
 
-	if dealerResult.Error == nil {
+	if hasDealer {
 		response.Dealer = &models.DealerResponse{
 			ID:         dealer.ID,
 			DealerCode: dealer.DealerCode,
@@ -175,19 +249,14 @@ func (ac *AuthController) SignInUser(ctx *gin.Context) {
 		}
 	}
 
-	if casinoID != nil {
-		response.Casino = &models.CasinoResponse{
-			ID:   casino.ID,
-			Name: casino.Name,
-		}
-	}
+	response.Casinos = casinos
 
 	ctx.JSON(http.StatusOK, response)
 }
 
 // RefreshAccessToken godoc
 // @Summary Refresh access token
-// @Description Get a new access token using refresh token
+// @Description Get a new access token using refresh token. The refresh token is rotated on every call; presenting a refresh token that was already rotated revokes its session.
 // @Tags authentication
 // @Produce json
 // @Success 200 {object} map[string]interface{}
@@ -204,33 +273,73 @@ func (ac *AuthController) RefreshAccessToken(ctx *gin.Context) {
 	}
 
 	config, _ := initializers.LoadConfig(".")
-
-	sub, err := utils.ValidateToken(cookie, config.RefreshTokenPublicKey)
+	refreshClaims, err := ac.Tokens.ValidateToken(utils.TokenTypeRefresh, cookie)
 	if err != nil {
 		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"status": "fail", "message": err.Error()})
 		return
 	}
 
+	sessionID, errSession := uuid.Parse(refreshClaims.SessionID)
+	tokenID, errToken := uuid.Parse(refreshClaims.TokenID)
+	if errSession != nil || errToken != nil {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"status": "fail", "message": message})
+		return
+	}
+
 	var user models.User
-	result := ac.DB.First(&user, "id = ?", fmt.Sprint(sub))
+	result := ac.DB.First(&user, "id = ?", refreshClaims.Subject)
 	if result.Error != nil {
 		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"status": "fail", "message": "the user belonging to this token no longer exists"})
 		return
 	}
 
-	claims := jwt.MapClaims{
-		"sub":  user.ID,
-		"role": user.Role,
-		"exp":  time.Now().Add(config.AccessTokenExpiresIn).Unix(),
+	var session models.Session
+	if err := ac.DB.First(&session, "id = ? AND user_id = ?", sessionID, user.ID).Error; err != nil {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"status": "fail", "message": message})
+		return
+	}
+
+	now := time.Now()
+	if !session.IsActive(now) {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"status": "fail", "message": "session has been revoked or has expired"})
+		return
+	}
+
+	// Only the latest token of the session may be swapped for a new one
+	newTokenID := uuid.New()
+	rotation := ac.DB.Model(&models.Session{}).
+		Where("id = ? AND current_token_id = ? AND revoked_at IS NULL", session.ID, tokenID).
+		Updates(map[string]interface{}{"current_token_id": newTokenID, "last_used_at": now, "updated_at": now})
+	if rotation.Error != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadGateway, gin.H{"status": "error", "message": message})
+		return
+	}
+	if rotation.RowsAffected == 0 {
+		// The token was rotated already, so it has leaked or been replayed
+		ac.DB.Model(&models.Session{}).Where("id = ? AND revoked_at IS NULL", session.ID).Update("revoked_at", now)
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"status": "fail", "message": "refresh token has already been used, session revoked"})
+		return
+	}
+
+	refresh_token, _, err := ac.Tokens.CreateToken(utils.TokenClaims{
+		Subject:   user.ID.String(),
+		SessionID: session.ID.String(),
+		TokenID:   newTokenID.String(),
+		Type:      utils.TokenTypeRefresh,
+	}, session.ExpiresAt.Sub(now))
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"status": "fail", "message": err.Error()})
+		return
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	access_token, err := token.SignedString([]byte(config.AccessTokenPrivateKey))
+
+	access_token, err := ac.createAccessToken(config, user, session.ID)
 	if err != nil {
 		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"status": "fail", "message": err.Error()})
 		return
 	}
 
 	ctx.SetCookie("access_token", access_token, config.AccessTokenMaxAge*60, "/", config.Domain, false, true)
+	ctx.SetCookie("refresh_token", refresh_token, config.RefreshTokenMaxAge*60, "/", config.Domain, false, true)
 	ctx.SetCookie("logged_in", "true", config.AccessTokenMaxAge*60, "/", config.Domain, false, false)
 
 	ctx.JSON(http.StatusOK, gin.H{"status": "success", "access_token": access_token})
@@ -238,17 +347,334 @@ func (ac *AuthController) RefreshAccessToken(ctx *gin.Context) {
 
 // LogoutUser godoc
 // @Summary Logout user
-// @Description Clear authentication cookies
+// @Description Revoke the session of the refresh token cookie and clear authentication cookies
 // @Tags authentication
 // @Produce json
 // @Success 200 {object} map[string]interface{}
 // @Router /auth/logout [post]
 func (ac *AuthController) LogoutUser(ctx *gin.Context) {
+	currentUser := ctx.MustGet("currentUser").(models.User)
 	config, _ := initializers.LoadConfig(".")
 
+	if sessionID, ok := currentSessionID(ctx, ac.Tokens); ok {
+		ac.DB.Model(&models.Session{}).
+			Where("id = ? AND user_id = ? AND revoked_at IS NULL", sessionID, currentUser.ID).
+			Update("revoked_at", time.Now())
+	}
+
 	ctx.SetCookie("access_token", "", -1, "/", config.Domain, false, true)
 	ctx.SetCookie("refresh_token", "", -1, "/", config.Domain, false, true)
 	ctx.SetCookie("logged_in", "", -1, "/", config.Domain, false, false)
 
 	ctx.JSON(http.StatusOK, gin.H{"status": "success"})
 }
+
+// FindSessions godoc
+// @Summary List active sessions
+// @Description Returns the current user's logins that can still be refreshed
+// @Tags authentication
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.SessionResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 502 {object} map[string]interface{}
+// @Router /auth/sessions [get]
+func (ac *AuthController) FindSessions(ctx *gin.Context) {
+	currentUser := ctx.MustGet("currentUser").(models.User)
+
+	var sessions []models.Session
+	if err := ac.DB.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", currentUser.ID, time.Now()).
+		Order("last_used_at DESC").
+		Find(&sessions).Error; err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Failed to fetch sessions"})
+		return
+	}
+
+	currentID, hasCurrent := currentSessionID(ctx, ac.Tokens)
+	responses := make([]models.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		responses[i] = models.SessionResponse{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			ClientIP:   session.ClientIP,
+			Current:    hasCurrent && session.ID == currentID,
+			ExpiresAt:  session.ExpiresAt,
+			LastUsedAt: session.LastUsedAt,
+			CreatedAt:  session.CreatedAt,
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "results": len(responses), "data": responses})
+}
+
+// RevokeSession godoc
+// @Summary Revoke a session
+// @Description Revokes one of the current user's sessions so its refresh token stops working
+// @Tags authentication
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Security BearerAuth
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 502 {object} map[string]interface{}
+// @Router /auth/sessions/{sessionId} [delete]
+func (ac *AuthController) RevokeSession(ctx *gin.Context) {
+	currentUser := ctx.MustGet("currentUser").(models.User)
+
+	sessionID, err := uuid.Parse(ctx.Param("sessionId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid session ID format"})
+		return
+	}
+
+	err = ac.DB.Transaction(func(tx *gorm.DB) error {
+		var session models.Session
+		if err := tx.First(&session, "id = ? AND user_id = ? AND revoked_at IS NULL", sessionID, currentUser.ID).Error; err != nil {
+			return err
+		}
+		before := session
+
+		result := tx.Model(&session).Where("revoked_at IS NULL").Update("revoked_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return recordAudit(tx, ctx, models.AuditActionUpdate, "session", session.ID, before, session)
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No active session with that ID exists"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Failed to revoke session"})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// createAccessToken issues an access token for user's login in session,
+// carrying the user's role and casino scope.
+func (ac *AuthController) createAccessToken(config initializers.Config, user models.User, sessionID uuid.UUID) (string, error) {
+	scope, err := middleware.LoadCasinoScope(ac.DB, user)
+	if err != nil {
+		return "", err
+	}
+
+	claims := utils.TokenClaims{
+		Subject:   user.ID.String(),
+		Role:      user.Role,
+		SessionID: sessionID.String(),
+		Type:      utils.TokenTypeAccess,
+	}
+	if !scope.Unrestricted {
+		claims.CasinoIDs = make([]string, len(scope.CasinoIDs))
+		for i, casinoID := range scope.CasinoIDs {
+			claims.CasinoIDs[i] = casinoID.String()
+		}
+	}
+
+	token, _, err := ac.Tokens.CreateToken(claims, config.AccessTokenExpiresIn)
+	return token, err
+}
+
+// currentSessionID reads the session of the request's refresh token cookie.
+func currentSessionID(ctx *gin.Context, tokens *utils.TokenService) (uuid.UUID, bool) {
+	cookie, err := ctx.Cookie("refresh_token")
+	if err != nil {
+		return uuid.Nil, false
+	}
+
+	refreshClaims, err := tokens.ValidateToken(utils.TokenTypeRefresh, cookie)
+	if err != nil {
+		return uuid.Nil, false
+	}
+
+	sessionID, err := uuid.Parse(refreshClaims.SessionID)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return sessionID, true
+}
+
+// VerifyEmail godoc
+// @Summary Verify email address
+// @Description Marks the user's email address as verified using the token from the verification mail. Tokens can be used once.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param request body models.VerifyEmailInput true "Verification token"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 502 {object} map[string]interface{}
+// @Router /auth/verify-email [post]
+func (ac *AuthController) VerifyEmail(ctx *gin.Context) {
+	var payload models.VerifyEmailInput
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	err := ac.DB.Transaction(func(tx *gorm.DB) error {
+		token, err := consumeUserToken(tx, payload.Token, models.TokenPurposeEmailVerification)
+		if err != nil {
+			return err
+		}
+		return updateUserAudited(tx, ctx, token.UserID, map[string]interface{}{"verified": true, "updated_at": time.Now()})
+	})
+	if errors.Is(err, errInvalidUserToken) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid or expired token"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Failed to verify email address"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "message": "Email address verified"})
+}
+
+// ResendVerification godoc
+// @Summary Resend the verification mail
+// @Description Sends a new verification link if the address belongs to an unverified user. The address is looked up and mailed after responding, so neither the response nor its timing tells whether it is registered. Requests are limited per address and client IP.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param request body models.EmailInput true "Email address"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{} "Too many mail requests"
+// @Router /auth/resend-verification [post]
+func (ac *AuthController) ResendVerification(ctx *gin.Context) {
+	var payload models.EmailInput
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	config, _ := initializers.LoadConfig(".")
+	email := strings.ToLower(payload.Email)
+	if wait := mailRequestBlocked(config, email, ctx.ClientIP()); wait > 0 {
+		respondTooManyRequests(ctx, wait, "mail requests")
+		return
+	}
+
+	go func() {
+		var user models.User
+		if err := ac.DB.First(&user, "email = ?", email).Error; err == nil && !user.Verified {
+			sendVerificationMail(ac.DB, config, user)
+		}
+	}()
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "message": "If the address needs verifying, a new link is on its way"})
+}
+
+// ForgotPassword godoc
+// @Summary Request a password reset
+// @Description Mails a password reset link if the address belongs to a user. The address is looked up and mailed after responding, so neither the response nor its timing tells whether it is registered. Requests are limited per address and client IP.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param request body models.EmailInput true "Email address"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{} "Too many mail requests"
+// @Router /auth/forgot-password [post]
+func (ac *AuthController) ForgotPassword(ctx *gin.Context) {
+	var payload models.EmailInput
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	config, _ := initializers.LoadConfig(".")
+	email := strings.ToLower(payload.Email)
+	if wait := mailRequestBlocked(config, email, ctx.ClientIP()); wait > 0 {
+		respondTooManyRequests(ctx, wait, "mail requests")
+		return
+	}
+
+	go func() {
+		var user models.User
+		if err := ac.DB.First(&user, "email = ?", email).Error; err == nil {
+			sendPasswordResetMail(ac.DB, config, user)
+		}
+	}()
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "message": "If an account exists for that address, a reset link is on its way"})
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Sets a new password using the token from the reset mail. Tokens can be used once, and every session of the user is revoked.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param request body models.ResetPasswordInput true "Reset token and new password"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 502 {object} map[string]interface{}
+// @Router /auth/reset-password [post]
+func (ac *AuthController) ResetPassword(ctx *gin.Context) {
+	var payload models.ResetPasswordInput
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	if payload.Password != payload.PasswordConfirm {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Passwords do not match"})
+		return
+	}
+
+	hashedPassword, err := utils.HashPassword(payload.Password)
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": err.Error()})
+		return
+	}
+
+	err = ac.DB.Transaction(func(tx *gorm.DB) error {
+		token, err := consumeUserToken(tx, payload.Token, models.TokenPurposePasswordReset)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		// Receiving the mail proves the address, so the user is verified too
+		if err := updateUserAudited(tx, ctx, token.UserID,
+			map[string]interface{}{"password": hashedPassword, "verified": true, "updated_at": now}); err != nil {
+			return err
+		}
+		return tx.Model(&models.Session{}).
+			Where("user_id = ? AND revoked_at IS NULL", token.UserID).
+			Update("revoked_at", now).Error
+	})
+	if errors.Is(err, errInvalidUserToken) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid or expired token"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Failed to reset password"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "message": "Password updated, please log in again"})
+}
+
+// updateUserAudited applies updates to a user and records the change. Hidden
+// fields such as the password hash are left out of the recorded diff.
+func updateUserAudited(tx *gorm.DB, ctx *gin.Context, userID uuid.UUID, updates map[string]interface{}) error {
+	var user models.User
+	if err := tx.First(&user, "id = ?", userID).Error; err != nil {
+		return err
+	}
+	before := user
+	if err := tx.Model(&user).Updates(updates).Error; err != nil {
+		return err
+	}
+	return recordAudit(tx, ctx, models.AuditActionUpdate, "user", user.ID, before, user)
+}
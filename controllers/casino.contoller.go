@@ -40,6 +40,11 @@ func (cc *CasinoController) CreateCasino(ctx *gin.Context) {
 		return
 	}
 
+	currency := payload.Currency
+	if currency == "" {
+		currency = models.DefaultCurrency
+	}
+
 	now := time.Now()
 
 	newCasino := models.Casino{
@@ -52,17 +57,23 @@ func (cc *CasinoController) CreateCasino(ctx *gin.Context) {
 		PhoneNumber:   payload.PhoneNumber,
 		MaxCapacity:   payload.MaxCapacity,
 		Status:        payload.Status,
+		Currency:      currency,
 		CreatedAt:     now,
 		UpdatedAt:     now,
 	}
 
-	result := cc.DB.Create(&newCasino)
-	if result.Error != nil {
-		if strings.Contains(result.Error.Error(), "duplicate key") {
+	err := cc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&newCasino).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, ctx, models.AuditActionCreate, "casino", newCasino.ID, nil, newCasino)
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
 			ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Casino with that name or license number already exists"})
 			return
 		}
-		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": result.Error.Error()})
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": err.Error()})
 		return
 	}
 
@@ -81,6 +92,7 @@ func (cc *CasinoController) CreateCasino(ctx *gin.Context) {
 //	@Success		200			{object}	models.Casino
 //	@Failure		400			{object}	map[string]interface{}
 //	@Failure		404			{object}	map[string]interface{}
+//	@Failure		409			{object}	map[string]interface{}
 //	@Router			/casinos/{casinoId} [put]
 func (cc *CasinoController) UpdateCasino(ctx *gin.Context) {
 	casinoId := ctx.Param("casinoId")
@@ -92,12 +104,25 @@ func (cc *CasinoController) UpdateCasino(ctx *gin.Context) {
 	}
 
 	var casino models.Casino
-	result := cc.DB.First(&casino, "id = ?", casinoId)
+	result := scopeToCasinos(ctx, cc.DB, "id").First(&casino, "id = ?", casinoId)
 	if result.Error != nil {
 		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No casino with that ID exists"})
 		return
 	}
 
+	// Amounts already booked or set at the casino are in its currency
+	if payload.Currency != "" && payload.Currency != casino.Currency {
+		inUse, err := cc.hasTablesOrSessions(casino.ID)
+		if err != nil {
+			ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Failed to check casino usage"})
+			return
+		}
+		if inUse {
+			ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "The currency of a casino with tables or game sessions cannot change"})
+			return
+		}
+	}
+
 	now := time.Now()
 	casinoToUpdate := models.Casino{
 		Name:          payload.Name,
@@ -110,10 +135,22 @@ func (cc *CasinoController) UpdateCasino(ctx *gin.Context) {
 		MaxCapacity:   payload.MaxCapacity,
 		Status:        payload.Status,
 		Rating:        payload.Rating,
+		Currency:      payload.Currency,
 		UpdatedAt:     now,
 	}
 
-	cc.DB.Model(&casino).Updates(casinoToUpdate)
+	before := casino
+	err := cc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&casino).Updates(casinoToUpdate).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, ctx, models.AuditActionUpdate, "casino", casino.ID, before, casino)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Failed to update casino"})
+		return
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": casino})
 }
 
@@ -138,7 +175,7 @@ func (cc *CasinoController) FindCasinoById(ctx *gin.Context) {
 	}
 
 	var casino models.Casino
-	result := cc.DB.First(&casino, "id = ?", casinoId)
+	result := scopeToCasinos(ctx, cc.DB, "id").First(&casino, "id = ?", casinoId)
 	if result.Error != nil {
 		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No casino with that ID exists"})
 		return
@@ -167,7 +204,7 @@ func (cc *CasinoController) FindCasinos(ctx *gin.Context) {
 	offset := (intPage - 1) * intLimit
 
 	var casinos []models.Casino
-	results := cc.DB.Limit(intLimit).Offset(offset).Find(&casinos)
+	results := scopeToCasinos(ctx, cc.DB, "id").Limit(intLimit).Offset(offset).Find(&casinos)
 	if results.Error != nil {
 		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": results.Error})
 		return
@@ -189,6 +226,7 @@ func (cc *CasinoController) FindCasinos(ctx *gin.Context) {
 			"max_capacity":   casino.MaxCapacity,
 			"status":         casino.Status,
 			"rating":         casino.Rating,
+			"currency":       casino.Currency,
 			"created_at":     casino.CreatedAt,
 			"updated_at":     casino.UpdatedAt,
 		}
@@ -212,12 +250,357 @@ func (cc *CasinoController) DeleteCasino(ctx *gin.Context) {
 	casinoId := ctx.Param("casinoId")
 
 	var casino models.Casino
-	result := cc.DB.First(&casino, "id = ?", casinoId)
+	result := scopeToCasinos(ctx, cc.DB, "id").First(&casino, "id = ?", casinoId)
 	if result.Error != nil {
 		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No casino with that ID exists"})
 		return
 	}
 
-	cc.DB.Delete(&casino)
+	err := cc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&casino).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, ctx, models.AuditActionDelete, "casino", casino.ID, casino, nil)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Failed to delete casino"})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// AddCasinoGame godoc
+//
+//	@Summary		Offer a game at a casino
+//	@Description	Link a game to a casino so sessions of that game can be played there
+//	@Tags			casinos
+//	@Produce		json
+//	@Param			casinoId	path		string	true	"Casino ID"
+//	@Param			gameId		path		string	true	"Game ID"
+//	@Success		201			{object}	map[string]interface{}
+//	@Failure		404			{object}	map[string]interface{}
+//	@Failure		409			{object}	map[string]interface{}
+//	@Failure		500			{object}	map[string]interface{}
+//	@Router			/casinos/{casinoId}/games/{gameId} [post]
+func (cc *CasinoController) AddCasinoGame(ctx *gin.Context) {
+	casino, ok := cc.findCasino(ctx)
+	if !ok {
+		return
+	}
+
+	var game models.Game
+	if err := cc.DB.First(&game, "id = ?", ctx.Param("gameId")).Error; err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No game with that ID exists"})
+		return
+	}
+
+	link := gin.H{"casino_id": casino.ID, "game_id": game.ID}
+	var inserted int64
+	err := cc.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec("INSERT INTO casino_games (casino_id, game_id) VALUES (?, ?) ON CONFLICT DO NOTHING", casino.ID, game.ID)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		inserted = result.RowsAffected
+		return recordAudit(tx, ctx, models.AuditActionCreate, "casino_game", casino.ID.String()+":"+game.ID.String(), nil, link)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to add game to casino"})
+		return
+	}
+	if inserted == 0 {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Game is already offered at this casino"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"status": "success", "data": link})
+}
+
+// RemoveCasinoGame godoc
+//
+//	@Summary		Stop offering a game at a casino
+//	@Description	Unlink a game from a casino. Sessions already recorded are kept.
+//	@Tags			casinos
+//	@Produce		json
+//	@Param			casinoId	path	string	true	"Casino ID"
+//	@Param			gameId		path	string	true	"Game ID"
+//	@Success		204			"No Content"
+//	@Failure		404			{object}	map[string]interface{}
+//	@Failure		500			{object}	map[string]interface{}
+//	@Router			/casinos/{casinoId}/games/{gameId} [delete]
+func (cc *CasinoController) RemoveCasinoGame(ctx *gin.Context) {
+	casino, ok := cc.findCasino(ctx)
+	if !ok {
+		return
+	}
+
+	gameId, err := uuid.Parse(ctx.Param("gameId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid game ID format"})
+		return
+	}
+
+	var deleted int64
+	err = cc.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec("DELETE FROM casino_games WHERE casino_id = ? AND game_id = ?", casino.ID, gameId)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		deleted = result.RowsAffected
+		return recordAudit(tx, ctx, models.AuditActionDelete, "casino_game", casino.ID.String()+":"+gameId.String(),
+			gin.H{"casino_id": casino.ID, "game_id": gameId}, nil)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to remove game from casino"})
+		return
+	}
+	if deleted == 0 {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "Game is not offered at this casino"})
+		return
+	}
+
 	ctx.JSON(http.StatusNoContent, nil)
 }
+
+// FindCasinoGames godoc
+//
+//	@Summary		List the games of a casino
+//	@Description	Get the games offered at a casino
+//	@Tags			casinos
+//	@Produce		json
+//	@Param			casinoId	path		string	true	"Casino ID"
+//	@Success		200			{array}		models.GameResponse
+//	@Failure		404			{object}	map[string]interface{}
+//	@Failure		500			{object}	map[string]interface{}
+//	@Router			/casinos/{casinoId}/games [get]
+func (cc *CasinoController) FindCasinoGames(ctx *gin.Context) {
+	casino, ok := cc.findCasino(ctx)
+	if !ok {
+		return
+	}
+
+	var games []models.Game
+	if err := cc.DB.Joins("JOIN casino_games cg ON cg.game_id = games.id").
+		Where("cg.casino_id = ?", casino.ID).
+		Order("games.name").
+		Find(&games).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch casino games"})
+		return
+	}
+
+	responses := make([]models.GameResponse, len(games))
+	for i, game := range games {
+		responses[i] = convertToGameResponse(game)
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "results": len(responses), "data": responses})
+}
+
+// AddCasinoDealer godoc
+//
+//	@Summary		Assign a dealer to a casino
+//	@Description	Assign a dealer to a casino so they can be planned on shifts and clock in there
+//	@Tags			casinos
+//	@Produce		json
+//	@Param			casinoId	path		string	true	"Casino ID"
+//	@Param			dealerId	path		string	true	"Dealer ID"
+//	@Success		201			{object}	map[string]interface{}
+//	@Failure		404			{object}	map[string]interface{}
+//	@Failure		409			{object}	map[string]interface{}
+//	@Failure		500			{object}	map[string]interface{}
+//	@Router			/casinos/{casinoId}/dealers/{dealerId} [post]
+func (cc *CasinoController) AddCasinoDealer(ctx *gin.Context) {
+	casino, ok := cc.findCasino(ctx)
+	if !ok {
+		return
+	}
+
+	var dealer models.Dealer
+	if err := cc.DB.First(&dealer, "id = ?", ctx.Param("dealerId")).Error; err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No dealer with that ID exists"})
+		return
+	}
+
+	link := gin.H{"casino_id": casino.ID, "dealer_id": dealer.ID}
+	var inserted int64
+	err := cc.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec("INSERT INTO casino_dealers (casino_id, dealer_id) VALUES (?, ?) ON CONFLICT DO NOTHING", casino.ID, dealer.ID)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		inserted = result.RowsAffected
+		return recordAudit(tx, ctx, models.AuditActionCreate, "casino_dealer", casino.ID.String()+":"+dealer.ID.String(), nil, link)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to assign dealer to casino"})
+		return
+	}
+	if inserted == 0 {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Dealer is already assigned to this casino"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"status": "success", "data": link})
+}
+
+// RemoveCasinoDealer godoc
+//
+//	@Summary		Unassign a dealer from a casino
+//	@Description	Remove a dealer from a casino. Dealers who are clocked in there must clock out first.
+//	@Tags			casinos
+//	@Produce		json
+//	@Param			casinoId	path	string	true	"Casino ID"
+//	@Param			dealerId	path	string	true	"Dealer ID"
+//	@Success		204			"No Content"
+//	@Failure		404			{object}	map[string]interface{}
+//	@Failure		409			{object}	map[string]interface{}
+//	@Failure		500			{object}	map[string]interface{}
+//	@Router			/casinos/{casinoId}/dealers/{dealerId} [delete]
+func (cc *CasinoController) RemoveCasinoDealer(ctx *gin.Context) {
+	casino, ok := cc.findCasino(ctx)
+	if !ok {
+		return
+	}
+
+	dealerId, err := uuid.Parse(ctx.Param("dealerId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid dealer ID format"})
+		return
+	}
+
+	clockedIn, err := isDealerClockedIn(cc.DB, dealerId, casino.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to check dealer shift"})
+		return
+	}
+	if clockedIn {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Dealer is clocked in at this casino"})
+		return
+	}
+
+	var deleted int64
+	err = cc.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec("DELETE FROM casino_dealers WHERE casino_id = ? AND dealer_id = ?", casino.ID, dealerId)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		deleted = result.RowsAffected
+		return recordAudit(tx, ctx, models.AuditActionDelete, "casino_dealer", casino.ID.String()+":"+dealerId.String(),
+			gin.H{"casino_id": casino.ID, "dealer_id": dealerId}, nil)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to unassign dealer from casino"})
+		return
+	}
+	if deleted == 0 {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "Dealer is not assigned to this casino"})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// FindCasinoDealers godoc
+//
+//	@Summary		List the dealers of a casino
+//	@Description	Get the dealers assigned to a casino
+//	@Tags			casinos
+//	@Produce		json
+//	@Param			casinoId	path		string	true	"Casino ID"
+//	@Success		200			{array}		models.DealerResponse
+//	@Failure		404			{object}	map[string]interface{}
+//	@Failure		500			{object}	map[string]interface{}
+//	@Router			/casinos/{casinoId}/dealers [get]
+func (cc *CasinoController) FindCasinoDealers(ctx *gin.Context) {
+	casino, ok := cc.findCasino(ctx)
+	if !ok {
+		return
+	}
+
+	var dealers []models.Dealer
+	if err := cc.DB.Preload("User").
+		Joins("JOIN casino_dealers cd ON cd.dealer_id = dealers.id").
+		Where("cd.casino_id = ?", casino.ID).
+		Order("dealers.dealer_code").
+		Find(&dealers).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch casino dealers"})
+		return
+	}
+
+	responses := make([]models.DealerResponse, len(dealers))
+	for i, dealer := range dealers {
+		responses[i] = models.DealerResponse{
+			ID:           dealer.ID,
+			User:         models.UserResponse{ID: dealer.User.ID, Name: dealer.User.Name, Email: dealer.User.Email},
+			DealerCode:   dealer.DealerCode,
+			Status:       dealer.Status,
+			GamesDealt:   dealer.GamesDealt,
+			Rating:       dealer.Rating,
+			LastActiveAt: dealer.LastActiveAt,
+			CreatedAt:    dealer.CreatedAt,
+			UpdatedAt:    dealer.UpdatedAt,
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "results": len(responses), "data": responses})
+}
+
+func (cc *CasinoController) findCasino(ctx *gin.Context) (models.Casino, bool) {
+	var casino models.Casino
+	if err := scopeToCasinos(ctx, cc.DB, "id").First(&casino, "id = ?", ctx.Param("casinoId")).Error; err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No casino with that ID exists"})
+		return models.Casino{}, false
+	}
+	return casino, true
+}
+
+// hasTablesOrSessions reports whether the casino has tables or game sessions,
+// deleted sessions included.
+func (cc *CasinoController) hasTablesOrSessions(casinoID uuid.UUID) (bool, error) {
+	var tables, sessions int64
+	if err := cc.DB.Model(&models.Table{}).Where("casino_id = ?", casinoID).Count(&tables).Error; err != nil {
+		return false, err
+	}
+	if err := cc.DB.Unscoped().Model(&models.GameSummary{}).Where("casino_id = ?", casinoID).Count(&sessions).Error; err != nil {
+		return false, err
+	}
+	return tables+sessions > 0, nil
+}
+
+// findCasinosByJoin returns the casinos linked to an entity through one of the
+// casino join tables, ordered by name.
+func findCasinosByJoin(db *gorm.DB, joinTable, column string, id string) ([]models.CasinoResponse, error) {
+	var casinos []models.Casino
+	if err := db.Joins("JOIN "+joinTable+" j ON j.casino_id = casinos.id").
+		Where("j."+column+" = ?", id).
+		Order("casinos.name").
+		Find(&casinos).Error; err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.CasinoResponse, len(casinos))
+	for i, casino := range casinos {
+		responses[i] = convertToCasinoResponse(casino)
+	}
+	return responses, nil
+}
+
+func convertToCasinoResponse(casino models.Casino) models.CasinoResponse {
+	return models.CasinoResponse{
+		ID:            casino.ID,
+		Name:          casino.Name,
+		Location:      casino.Location,
+		LicenseNumber: casino.LicenseNumber,
+		Description:   casino.Description,
+		OpeningHours:  casino.OpeningHours,
+		Website:       casino.Website,
+		PhoneNumber:   casino.PhoneNumber,
+		MaxCapacity:   casino.MaxCapacity,
+		Status:        casino.Status,
+		Rating:        casino.Rating,
+		Currency:      casino.Currency,
+		CreatedAt:     casino.CreatedAt,
+		UpdatedAt:     casino.UpdatedAt,
+	}
+}
@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/suidevv/tableye-api/models"
+	"gorm.io/gorm"
+)
+
+// casinoScope returns the casinos the current user may access, as set by
+// middleware.ScopeCasinos. Without it the scope is empty, so nothing leaks
+// from a route that forgot the middleware.
+func casinoScope(ctx *gin.Context) models.CasinoScope {
+	if value, exists := ctx.Get("casinoScope"); exists {
+		if scope, ok := value.(models.CasinoScope); ok {
+			return scope
+		}
+	}
+	return models.CasinoScope{}
+}
+
+// scopeToCasinos restricts a query to rows whose casino column holds one of the
+// current user's casinos.
+func scopeToCasinos(ctx *gin.Context, query *gorm.DB, column string) *gorm.DB {
+	scope := casinoScope(ctx)
+	if scope.Unrestricted {
+		return query
+	}
+	if len(scope.CasinoIDs) == 0 {
+		return query.Where("1 = 0")
+	}
+	return query.Where(column+" IN ?", scope.CasinoIDs)
+}
+
+// requireCasinoAccess responds with 403 and returns false when the casino is
+// outside the current user's scope.
+func requireCasinoAccess(ctx *gin.Context, casinoID uuid.UUID) bool {
+	if casinoScope(ctx).Allows(casinoID) {
+		return true
+	}
+	ctx.JSON(http.StatusForbidden, gin.H{"status": "fail", "message": "You do not have access to this casino"})
+	return false
+}
+
+// scopeToGameSummaries restricts a query to rows whose game summary column
+// points at a game summary in one of the current user's casinos. Deleted game
+// summaries count too, so their deleted transactions can still be found.
+func scopeToGameSummaries(ctx *gin.Context, query *gorm.DB, column string) *gorm.DB {
+	if casinoScope(ctx).Unrestricted {
+		return query
+	}
+	gameSummaryIDs := scopeToCasinos(ctx, query.Session(&gorm.Session{NewDB: true}).Unscoped().Model(&models.GameSummary{}).Select("id"), "casino_id")
+	return query.Where(column+" IN (?)", gameSummaryIDs)
+}
+
+// scopeToDealers restricts a dealers query to dealers assigned to one of the
+// current user's casinos.
+func scopeToDealers(ctx *gin.Context, query *gorm.DB) *gorm.DB {
+	if casinoScope(ctx).Unrestricted {
+		return query
+	}
+	dealerIDs := scopeToCasinos(ctx, query.Session(&gorm.Session{NewDB: true}).Table("casino_dealers").Select("dealer_id"), "casino_id")
+	return query.Where("dealers.id IN (?)", dealerIDs)
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"database/sql"
 	"net/http"
 	"strconv"
 	"strings"
@@ -57,13 +58,18 @@ func (dc *DealerController) CreateDealer(ctx *gin.Context) {
 		UpdatedAt:  now,
 	}
 
-	result := dc.DB.Create(&newDealer)
-	if result.Error != nil {
-		if strings.Contains(result.Error.Error(), "duplicate key") {
+	err = dc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&newDealer).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, ctx, models.AuditActionCreate, "dealer", newDealer.ID, nil, newDealer)
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
 			ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Dealer with that user ID or dealer code already exists"})
 			return
 		}
-		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": result.Error.Error()})
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": err.Error()})
 		return
 	}
 
@@ -104,7 +110,7 @@ func (dc *DealerController) UpdateDealer(ctx *gin.Context) {
 	}
 
 	var dealer models.Dealer
-	result := dc.DB.First(&dealer, "id = ?", dealerId)
+	result := scopeToDealers(ctx, dc.DB).First(&dealer, "id = ?", dealerId)
 	if result.Error != nil {
 		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No dealer with that ID exists"})
 		return
@@ -112,14 +118,21 @@ func (dc *DealerController) UpdateDealer(ctx *gin.Context) {
 
 	now := time.Now()
 	dealerToUpdate := models.Dealer{
-		Status:       payload.Status,
-		GamesDealt:   payload.GamesDealt,
-		Rating:       payload.Rating,
-		LastActiveAt: payload.LastActiveAt,
-		UpdatedAt:    now,
+		Status:    payload.Status,
+		UpdatedAt: now,
 	}
 
-	dc.DB.Model(&dealer).Updates(dealerToUpdate)
+	before := dealer
+	err := dc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&dealer).Updates(dealerToUpdate).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, ctx, models.AuditActionUpdate, "dealer", dealer.ID, before, dealer)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Failed to update dealer"})
+		return
+	}
 
 	dealerResponse := models.DealerResponse{
 		ID:           dealer.ID,
@@ -150,7 +163,7 @@ func (dc *DealerController) FindDealerById(ctx *gin.Context) {
 	dealerId := ctx.Param("dealerId")
 
 	var dealer models.Dealer
-	result := dc.DB.First(&dealer, "id = ?", dealerId)
+	result := scopeToDealers(ctx, dc.DB).First(&dealer, "id = ?", dealerId)
 	if result.Error != nil {
 		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No dealer with that ID exists"})
 		return
@@ -191,7 +204,7 @@ func (dc *DealerController) FindDealers(ctx *gin.Context) {
 	offset := (intPage - 1) * intLimit
 
 	var dealers []models.Dealer
-	results := dc.DB.Preload("User").Limit(intLimit).Offset(offset).Find(&dealers)
+	results := scopeToDealers(ctx, dc.DB.Preload("User")).Limit(intLimit).Offset(offset).Find(&dealers)
 	if results.Error != nil {
 		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": results.Error})
 		return
@@ -238,12 +251,190 @@ func (dc *DealerController) DeleteDealer(ctx *gin.Context) {
 	dealerId := ctx.Param("dealerId")
 
 	var dealer models.Dealer
-	result := dc.DB.First(&dealer, "id = ?", dealerId)
+	result := scopeToDealers(ctx, dc.DB).First(&dealer, "id = ?", dealerId)
 	if result.Error != nil {
 		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No dealer with that ID exists"})
 		return
 	}
 
-	dc.DB.Delete(&dealer)
+	err := dc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&dealer).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, ctx, models.AuditActionDelete, "dealer", dealer.ID, dealer, nil)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Failed to delete dealer"})
+		return
+	}
+
 	ctx.JSON(http.StatusNoContent, nil)
 }
+
+// FindDealerCasinos godoc
+//
+//	@Summary		List the casinos of a dealer
+//	@Description	Get the casinos a dealer is assigned to
+//	@Tags			dealers
+//	@Produce		json
+//	@Param			dealerId	path		string	true	"Dealer ID"
+//	@Success		200			{array}		models.CasinoResponse
+//	@Failure		404			{object}	map[string]interface{}
+//	@Failure		500			{object}	map[string]interface{}
+//	@Router			/dealers/{dealerId}/casinos [get]
+func (dc *DealerController) FindDealerCasinos(ctx *gin.Context) {
+	var dealer models.Dealer
+	if err := scopeToDealers(ctx, dc.DB).First(&dealer, "id = ?", ctx.Param("dealerId")).Error; err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No dealer with that ID exists"})
+		return
+	}
+
+	casinos, err := findCasinosByJoin(scopeToCasinos(ctx, dc.DB, "casinos.id"), "casino_dealers", "dealer_id", dealer.ID.String())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch dealer casinos"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "results": len(casinos), "data": casinos})
+}
+
+// FindDealerPerformance godoc
+//
+//	@Summary		Get dealer performance
+//	@Description	Get games dealt, pace, session length, void rate, error rate and rating of a dealer computed from their game summaries. Only finished sessions are scored, so sessions still running do not lower the rating.
+//	@Tags			dealers
+//	@Accept			json
+//	@Produce		json
+//	@Param			dealerId	path		string	true	"Dealer ID"
+//	@Param			from		query		string	false	"Sessions starting from this date (YYYY-MM-DD or RFC 3339)"
+//	@Param			to			query		string	false	"Sessions starting before the end of this date (YYYY-MM-DD or RFC 3339)"
+//	@Success		200			{object}	models.DealerPerformanceResponse
+//	@Failure		400			{object}	map[string]interface{}
+//	@Failure		404			{object}	map[string]interface{}
+//	@Failure		500			{object}	map[string]interface{}
+//	@Router			/dealers/{dealerId}/performance [get]
+func (dc *DealerController) FindDealerPerformance(ctx *gin.Context) {
+	dealerId := ctx.Param("dealerId")
+
+	from, to, err := parseTimeRange(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	var dealer models.Dealer
+	result := scopeToDealers(ctx, dc.DB).First(&dealer, "id = ?", dealerId)
+	if result.Error != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No dealer with that ID exists"})
+		return
+	}
+
+	performance, err := computeDealerPerformance(dc.DB, dealer.ID, from, to)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to compute dealer performance"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": performance})
+}
+
+// computeDealerPerformance derives a dealer's metrics from the game summaries they
+// dealt. Pace, session length and the error rate only consider completed
+// sessions, as those are the only ones with a reliable end time and settled
+// transactions. The void rate is over completed and voided sessions. A dealer
+// without completed sessions is not rated yet.
+//
+// Errors are the corrections booked in the dealer's sessions: each one fixes a
+// transaction that was recorded wrongly at the table.
+func computeDealerPerformance(db *gorm.DB, dealerID uuid.UUID, from, to *time.Time) (models.DealerPerformanceResponse, error) {
+	var totals struct {
+		GamesDealt        int64
+		CompletedSessions int64
+		VoidedSessions    int64
+		RoundsDealt       int64
+		SecondsDealt      float64
+	}
+
+	query := db.Model(&models.GameSummary{}).
+		Select(`COUNT(*) AS games_dealt,
+			COUNT(*) FILTER (WHERE status = @completed) AS completed_sessions,
+			COUNT(*) FILTER (WHERE status = @voided) AS voided_sessions,
+			COALESCE(SUM(rounds_played) FILTER (WHERE status = @completed), 0) AS rounds_dealt,
+			COALESCE(SUM(EXTRACT(EPOCH FROM end_time - start_time)) FILTER (WHERE status = @completed), 0) AS seconds_dealt`,
+			sql.Named("completed", models.GameSummaryStatusCompleted),
+			sql.Named("voided", models.GameSummaryStatusVoided)).
+		Where("dealer_id = ?", dealerID)
+	if from != nil {
+		query = query.Where("start_time >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("start_time < ?", *to)
+	}
+	if err := query.Scan(&totals).Error; err != nil {
+		return models.DealerPerformanceResponse{}, err
+	}
+
+	var entries struct {
+		Transactions int64
+		Corrections  int64
+	}
+	entriesQuery := db.Table("transactions t").
+		Select(`COUNT(*) FILTER (WHERE t.reverses_id IS NULL AND t.corrects_id IS NULL) AS transactions,
+			COUNT(*) FILTER (WHERE t.corrects_id IS NOT NULL) AS corrections`).
+		Joins("JOIN game_summaries gs ON gs.id = t.game_summary_id AND gs.deleted_at IS NULL").
+		Where("gs.dealer_id = ? AND gs.status = ? AND t.deleted_at IS NULL", dealerID, models.GameSummaryStatusCompleted)
+	if from != nil {
+		entriesQuery = entriesQuery.Where("gs.start_time >= ?", *from)
+	}
+	if to != nil {
+		entriesQuery = entriesQuery.Where("gs.start_time < ?", *to)
+	}
+	if err := entriesQuery.Scan(&entries).Error; err != nil {
+		return models.DealerPerformanceResponse{}, err
+	}
+
+	performance := models.DealerPerformanceResponse{
+		DealerID:          dealerID,
+		From:              from,
+		To:                to,
+		GamesDealt:        totals.GamesDealt,
+		CompletedSessions: totals.CompletedSessions,
+		VoidedSessions:    totals.VoidedSessions,
+		RoundsDealt:       totals.RoundsDealt,
+		HoursDealt:        totals.SecondsDealt / 3600,
+		Corrections:       entries.Corrections,
+	}
+
+	if performance.HoursDealt > 0 {
+		performance.RoundsPerHour = float64(totals.RoundsDealt) / performance.HoursDealt
+	}
+	if totals.CompletedSessions > 0 {
+		performance.AverageSessionMinutes = totals.SecondsDealt / 60 / float64(totals.CompletedSessions)
+	}
+	if finished := totals.CompletedSessions + totals.VoidedSessions; finished > 0 {
+		performance.VoidRate = float64(totals.VoidedSessions) / float64(finished)
+	}
+	if entries.Transactions > 0 {
+		performance.ErrorRate = float64(entries.Corrections) / float64(entries.Transactions)
+	}
+	if totals.CompletedSessions > 0 {
+		performance.Rating = models.DealerRating(performance.RoundsPerHour, performance.VoidRate, performance.ErrorRate)
+	}
+
+	return performance, nil
+}
+
+// RefreshDealerPerformance stores the dealer's all-time games dealt and rating. It
+// must run in the same DB transaction as the game summary change that affects them.
+func RefreshDealerPerformance(tx *gorm.DB, dealerID uuid.UUID) error {
+	performance, err := computeDealerPerformance(tx, dealerID, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(&models.Dealer{}).Where("id = ?", dealerID).Updates(map[string]interface{}{
+		"games_dealt": performance.GamesDealt,
+		"rating":      performance.Rating,
+		"updated_at":  time.Now(),
+	}).Error
+}
@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/suidevv/tableye-api/models"
+	"gorm.io/gorm"
+)
+
+// missingRateError means an amount could not be converted because no exchange
+// rate was set for one of the currencies on or before the day.
+type missingRateError struct {
+	From string
+	To   string
+	Day  time.Time
+}
+
+func (e *missingRateError) Error() string {
+	return fmt.Sprintf("No exchange rate to convert %s to %s on %s", e.From, e.To, e.Day.Format("2006-01-02"))
+}
+
+// respondMissingRate answers with 422 if err is a missing exchange rate and
+// reports whether it did.
+func respondMissingRate(ctx *gin.Context, err error) bool {
+	var missing *missingRateError
+	if !errors.As(err, &missing) {
+		return false
+	}
+	ctx.JSON(http.StatusUnprocessableEntity, gin.H{"status": "fail", "message": missing.Error()})
+	return true
+}
+
+// convertMoney converts amount between currencies with the rates in force on
+// day, rounding to the cent.
+func convertMoney(db *gorm.DB, amount models.Money, from, to string, day time.Time) (models.Money, error) {
+	if from == to {
+		return amount, nil
+	}
+
+	var converted *models.Money
+	if err := db.Raw(`SELECT ROUND(src.amount * `+models.ConversionRateSQL("src.currency", "src.day", to)+`, 2)
+		FROM (SELECT ?::numeric AS amount, ?::text AS currency, ?::date AS day) src`, amount, from, day).
+		Row().Scan(&converted); err != nil {
+		return 0, err
+	}
+	if converted == nil {
+		return 0, &missingRateError{From: from, To: to, Day: day}
+	}
+	return *converted, nil
+}
@@ -26,7 +26,7 @@ func NewGameController(DB *gorm.DB) GameController {
 //	@Accept			json
 //	@Produce		json
 //	@Param			game	body		models.CreateGameRequest	true	"Create game request"
-//	@Success		201		{object}	models.Game
+//	@Success		201		{object}	models.GameResponse
 //	@Failure		400		{object}	map[string]interface{}
 //	@Failure		409		{object}	map[string]interface{}
 //	@Failure		502		{object}	map[string]interface{}
@@ -51,17 +51,22 @@ func (gc *GameController) CreateGame(ctx *gin.Context) {
 		UpdatedAt:   now,
 	}
 
-	result := gc.DB.Create(&newGame)
-	if result.Error != nil {
-		if strings.Contains(result.Error.Error(), "duplicate key") {
+	err := gc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&newGame).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, ctx, models.AuditActionCreate, "game", newGame.ID, nil, newGame)
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
 			ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Game with that name already exists"})
 			return
 		}
-		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": result.Error.Error()})
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, gin.H{"status": "success", "data": newGame})
+	ctx.JSON(http.StatusCreated, gin.H{"status": "success", "data": convertToGameResponse(newGame)})
 }
 
 // UpdateGame godoc
@@ -72,7 +77,7 @@ func (gc *GameController) CreateGame(ctx *gin.Context) {
 //	@Produce		json
 //	@Param			gameId	path		string						true	"Game ID"
 //	@Param			game	body		models.UpdateGameRequest	true	"Update game request"
-//	@Success		200		{object}	models.Game
+//	@Success		200		{object}	models.GameResponse
 //	@Failure		400		{object}	map[string]interface{}
 //	@Failure		404		{object}	map[string]interface{}
 //	@Router			/games/{gameId} [put]
@@ -104,8 +109,19 @@ func (gc *GameController) UpdateGame(ctx *gin.Context) {
 		UpdatedAt:   now,
 	}
 
-	gc.DB.Model(&game).Updates(gameToUpdate)
-	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": game})
+	before := game
+	err := gc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&game).Updates(gameToUpdate).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, ctx, models.AuditActionUpdate, "game", game.ID, before, game)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Failed to update game"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": convertToGameResponse(game)})
 }
 
 // FindGameById godoc
@@ -157,7 +173,12 @@ func (gc *GameController) FindGames(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"status": "success", "results": len(games), "data": games})
+	responses := make([]models.GameResponse, len(games))
+	for i, game := range games {
+		responses[i] = convertToGameResponse(game)
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "results": len(responses), "data": responses})
 }
 
 // DeleteGame godoc
@@ -180,6 +201,59 @@ func (gc *GameController) DeleteGame(ctx *gin.Context) {
 		return
 	}
 
-	gc.DB.Delete(&game)
+	err := gc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&game).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, ctx, models.AuditActionDelete, "game", game.ID, game, nil)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Failed to delete game"})
+		return
+	}
+
 	ctx.JSON(http.StatusNoContent, nil)
 }
+
+// FindGameCasinos godoc
+//
+//	@Summary		List the casinos offering a game
+//	@Description	Get the casinos where a game is offered
+//	@Tags			games
+//	@Produce		json
+//	@Param			gameId	path		string	true	"Game ID"
+//	@Success		200		{array}		models.CasinoResponse
+//	@Failure		404		{object}	map[string]interface{}
+//	@Failure		500		{object}	map[string]interface{}
+//	@Router			/games/{gameId}/casinos [get]
+func (gc *GameController) FindGameCasinos(ctx *gin.Context) {
+	var game models.Game
+	if err := gc.DB.First(&game, "id = ?", ctx.Param("gameId")).Error; err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No game with that ID exists"})
+		return
+	}
+
+	casinos, err := findCasinosByJoin(scopeToCasinos(ctx, gc.DB, "casinos.id"), "casino_games", "game_id", game.ID.String())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch game casinos"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "results": len(casinos), "data": casinos})
+}
+
+func convertToGameResponse(game models.Game) models.GameResponse {
+	return models.GameResponse{
+		ID:          game.ID,
+		Name:        game.Name,
+		Type:        game.Type,
+		Description: game.Description,
+		MaxPlayers:  game.MaxPlayers,
+		MinPlayers:  game.MinPlayers,
+		MinBet:      game.MinBet,
+		MaxBet:      game.MaxBet,
+		Currency:    models.DefaultCurrency,
+		CreatedAt:   game.CreatedAt,
+		UpdatedAt:   game.UpdatedAt,
+	}
+}
@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/suidevv/tableye-api/models"
+	"gorm.io/gorm"
+)
+
+func respondRuleViolation(ctx *gin.Context, violation *models.RuleViolation) {
+	response := gin.H{
+		"status":  "fail",
+		"rule":    violation.Rule,
+		"message": violation.Message,
+		"limit":   violation.Limit,
+	}
+	if violation.BetLimit != nil {
+		response["bet_limit"] = violation.BetLimit
+		response["currency"] = violation.Currency
+	}
+	ctx.JSON(http.StatusUnprocessableEntity, response)
+}
+
+// checkSessionRules checks a new session against its game: the game must be
+// offered at the casino and the number of distinct players must be within the
+// game's limits.
+func checkSessionRules(db *gorm.DB, gameSummary models.GameSummary, playerIDs []string) (*models.RuleViolation, error) {
+	var game models.Game
+	if err := db.First(&game, "id = ?", gameSummary.GameID).Error; err != nil {
+		return nil, errors.New("no game with that ID exists")
+	}
+
+	var offered int64
+	if err := db.Table("casino_games").Where("casino_id = ? AND game_id = ?", gameSummary.CasinoID, game.ID).Count(&offered).Error; err != nil {
+		return nil, err
+	}
+	if offered == 0 {
+		return &models.RuleViolation{Rule: models.RuleGameNotAtCasino, Message: "Game is not offered at this casino"}, nil
+	}
+
+	players := make(map[string]bool, len(playerIDs))
+	for _, id := range playerIDs {
+		players[id] = true
+	}
+
+	return game.CheckPlayerCount(len(players)), nil
+}
+
+// checkBetRules checks a transaction amount against the bet limits of the
+// session's game, using the table's overrides when the session runs on one.
+// Game limits are in the reporting currency and converted to the session's on
+// its start date; table limits are already in the casino's currency.
+func checkBetRules(db *gorm.DB, gameSummaryID uuid.UUID, amount models.Money) (*models.RuleViolation, error) {
+	var gameSummary models.GameSummary
+	if err := db.Preload("Table").First(&gameSummary, "id = ?", gameSummaryID).Error; err != nil {
+		return nil, err
+	}
+
+	var game models.Game
+	if err := db.First(&game, "id = ?", gameSummary.GameID).Error; err != nil {
+		return nil, err
+	}
+
+	// Only game limits the table does not override need a rate, and a zero
+	// limit is zero in any currency
+	table := gameSummary.Table
+	for _, limit := range []struct {
+		amount     *models.Money
+		overridden bool
+	}{
+		{&game.MinBet, table != nil && table.MinBet != nil},
+		{&game.MaxBet, table != nil && table.MaxBet != nil},
+	} {
+		if limit.overridden || *limit.amount == 0 {
+			continue
+		}
+		converted, err := convertMoney(db, *limit.amount, models.DefaultCurrency, gameSummary.Currency, gameSummary.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		*limit.amount = converted
+	}
+
+	minBet, maxBet := game.BetLimits(table)
+	violation := models.CheckBetAmount(amount, minBet, maxBet)
+	if violation != nil {
+		violation.Currency = gameSummary.Currency
+	}
+	return violation, nil
+}
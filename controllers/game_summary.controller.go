@@ -1,7 +1,9 @@
 package controllers
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -10,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/suidevv/tableye-api/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type GameSummaryController struct {
@@ -22,13 +25,17 @@ func NewGameSummaryController(DB *gorm.DB) GameSummaryController {
 
 // CreateGameSummary godoc
 // @Summary Create a new game summary
-// @Description Create a new game summary with the input payload
+// @Description Create a new game summary with the input payload. The dealer must be clocked in at the casino,
+// @Description the game must be offered there and the number of players must be within the game's limits.
 // @Tags game-summaries
 // @Accept json
 // @Produce json
 // @Param payload body models.CreateGameSummaryRequest true "Create game summary payload"
 // @Success 201 {object} models.GameSummaryResponse
 // @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} models.RuleViolation
 // @Failure 500 {object} map[string]interface{}
 // @Router /game-summaries [post]
 func (gsc *GameSummaryController) CreateGameSummary(ctx *gin.Context) {
@@ -44,12 +51,46 @@ func (gsc *GameSummaryController) CreateGameSummary(ctx *gin.Context) {
 		return
 	}
 
+	if !requireCasinoAccess(ctx, newGameSummary.CasinoID) {
+		return
+	}
+
+	violation, err := checkSessionRules(gsc.DB, newGameSummary, payload.PlayerIDs)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+	if violation != nil {
+		respondRuleViolation(ctx, violation)
+		return
+	}
+
+	clockedIn, err := isDealerClockedIn(gsc.DB, newGameSummary.DealerID, newGameSummary.CasinoID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to check dealer shift"})
+		return
+	}
+	if !clockedIn {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Dealer is not clocked in at this casino"})
+		return
+	}
+
 	if err := gsc.DB.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(&newGameSummary).Error; err != nil {
 			return err
 		}
-		return gsc.addPlayersToGameSummary(tx, newGameSummary.ID, payload.PlayerIDs)
+		if err := recordAudit(tx, ctx, models.AuditActionCreate, "game_summary", newGameSummary.ID, nil, newGameSummary); err != nil {
+			return err
+		}
+		if err := gsc.addPlayersToGameSummary(tx, newGameSummary.ID, payload.PlayerIDs); err != nil {
+			return err
+		}
+		return RefreshDealerPerformance(tx, newGameSummary.DealerID)
 	}); err != nil {
+		if errors.Is(err, errUnknownPlayer) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to create game summary"})
 		return
 	}
@@ -89,12 +130,22 @@ func (gsc *GameSummaryController) UpdateGameSummary(ctx *gin.Context) {
 		return
 	}
 
-	if err := gsc.DB.Model(&models.GameSummary{}).Where("id = ?", gameSummaryId).Updates(payload).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No game summary with that ID exists"})
-		} else {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to update game summary"})
+	before, ok := gsc.findScopedGameSummary(ctx, gameSummaryId)
+	if !ok {
+		return
+	}
+
+	if err := gsc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.GameSummary{}).Where("id = ?", gameSummaryId).Updates(payload).Error; err != nil {
+			return err
+		}
+		var after models.GameSummary
+		if err := tx.First(&after, "id = ?", gameSummaryId).Error; err != nil {
+			return err
 		}
+		return recordAudit(tx, ctx, models.AuditActionUpdate, "game_summary", gameSummaryId, before, after)
+	}); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to update game summary"})
 		return
 	}
 
@@ -126,6 +177,10 @@ func (gsc *GameSummaryController) FindGameSummaryById(ctx *gin.Context) {
 		return
 	}
 
+	if _, ok := gsc.findScopedGameSummary(ctx, gameSummaryId); !ok {
+		return
+	}
+
 	response, err := gsc.getGameSummaryResponse(gameSummaryId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -156,7 +211,7 @@ func (gsc *GameSummaryController) FindGameSummaries(ctx *gin.Context) {
 	offset := (page - 1) * limit
 
 	var gameSummaries []models.GameSummary
-	if err := gsc.DB.Order("created_at DESC").Limit(limit).Offset(offset).Find(&gameSummaries).Error; err != nil {
+	if err := scopeToCasinos(ctx, gsc.DB, "casino_id").Order("created_at DESC").Limit(limit).Offset(offset).Find(&gameSummaries).Error; err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch game summaries"})
 		return
 	}
@@ -189,26 +244,54 @@ func (gsc *GameSummaryController) DeleteGameSummary(ctx *gin.Context) {
 		return
 	}
 
+	if _, ok := gsc.findScopedGameSummary(ctx, gameSummaryId); !ok {
+		return
+	}
+
 	err = gsc.DB.Transaction(func(tx *gorm.DB) error {
-		// Delete related records in game_players table
-		if err := tx.Exec("DELETE FROM game_players WHERE game_summary_id = ?", gameSummaryId).Error; err != nil {
+		var gameSummary models.GameSummary
+		if err := tx.First(&gameSummary, "id = ?", gameSummaryId).Error; err != nil {
 			return err
 		}
 
-		// Delete related transactions
-		if err := tx.Where("game_summary_id = ?", gameSummaryId).Delete(&models.Transaction{}).Error; err != nil {
+		// Reverse the players' winnings while the transactions still count,
+		// converting each one as adjustPlayerWinnings did when it was booked
+		if err := tx.Exec(`
+			UPDATE players SET total_winnings = players.total_winnings - settled.amount, updated_at = NOW()
+			FROM (
+				SELECT t.player_id, SUM(ROUND(t.amount * `+models.ConversionRateSQL("t.currency", "gs.start_time", models.DefaultCurrency)+`, 2)) AS amount
+				FROM transactions t
+				JOIN game_summaries gs ON gs.id = t.game_summary_id
+				WHERE t.game_summary_id = ? AND t.deleted_at IS NULL
+				GROUP BY t.player_id
+			) AS settled
+			WHERE players.id = settled.player_id`, gameSummaryId).Error; err != nil {
 			return err
 		}
 
-		// Delete the game summary
-		result := tx.Delete(&models.GameSummary{}, gameSummaryId)
-		if result.Error != nil {
-			return result.Error
+		// The session and its transactions share one deletion time so a restore
+		// brings back exactly this set. Players and rounds stay linked to it.
+		deletedAt := time.Now()
+		var transactions []models.Transaction
+		if err := tx.Where("game_summary_id = ?", gameSummaryId).Find(&transactions).Error; err != nil {
+			return err
 		}
-		if result.RowsAffected == 0 {
-			return gorm.ErrRecordNotFound
+		for _, transaction := range transactions {
+			if err := recordAudit(tx, ctx, models.AuditActionDelete, "transaction", transaction.ID, transaction, nil); err != nil {
+				return err
+			}
 		}
-		return nil
+		if err := tx.Model(&models.Transaction{}).Where("game_summary_id = ?", gameSummaryId).Update("deleted_at", deletedAt).Error; err != nil {
+			return err
+		}
+
+		if err := recordAudit(tx, ctx, models.AuditActionDelete, "game_summary", gameSummary.ID, gameSummary, nil); err != nil {
+			return err
+		}
+		if err := tx.Model(&gameSummary).Update("deleted_at", deletedAt).Error; err != nil {
+			return err
+		}
+		return RefreshDealerPerformance(tx, gameSummary.DealerID)
 	})
 
 	if err != nil {
@@ -223,6 +306,162 @@ func (gsc *GameSummaryController) DeleteGameSummary(ctx *gin.Context) {
 	ctx.JSON(http.StatusNoContent, nil)
 }
 
+// StartGameSummary godoc
+// @Summary Start a game summary
+// @Description Move a scheduled game summary to in progress
+// @Tags game-summaries
+// @Produce json
+// @Param gameSummaryId path string true "Game Summary ID"
+// @Success 200 {object} models.GameSummaryResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /game-summaries/{gameSummaryId}/start [post]
+func (gsc *GameSummaryController) StartGameSummary(ctx *gin.Context) {
+	gsc.transitionGameSummary(ctx, models.GameSummaryStatusScheduled, models.GameSummaryStatusInProgress)
+}
+
+// PauseGameSummary godoc
+// @Summary Pause a game summary
+// @Description Pause a game summary that is in progress
+// @Tags game-summaries
+// @Produce json
+// @Param gameSummaryId path string true "Game Summary ID"
+// @Success 200 {object} models.GameSummaryResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /game-summaries/{gameSummaryId}/pause [post]
+func (gsc *GameSummaryController) PauseGameSummary(ctx *gin.Context) {
+	gsc.transitionGameSummary(ctx, models.GameSummaryStatusInProgress, models.GameSummaryStatusPaused)
+}
+
+// ResumeGameSummary godoc
+// @Summary Resume a game summary
+// @Description Resume a paused game summary
+// @Tags game-summaries
+// @Produce json
+// @Param gameSummaryId path string true "Game Summary ID"
+// @Success 200 {object} models.GameSummaryResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /game-summaries/{gameSummaryId}/resume [post]
+func (gsc *GameSummaryController) ResumeGameSummary(ctx *gin.Context) {
+	gsc.transitionGameSummary(ctx, models.GameSummaryStatusPaused, models.GameSummaryStatusInProgress)
+}
+
+// CloseGameSummary godoc
+// @Summary Close a game summary
+// @Description Complete a running or paused game summary and stamp its end time
+// @Tags game-summaries
+// @Produce json
+// @Param gameSummaryId path string true "Game Summary ID"
+// @Success 200 {object} models.GameSummaryResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /game-summaries/{gameSummaryId}/close [post]
+func (gsc *GameSummaryController) CloseGameSummary(ctx *gin.Context) {
+	gsc.transitionGameSummary(ctx, "", models.GameSummaryStatusCompleted)
+}
+
+// VoidGameSummary godoc
+// @Summary Void a game summary
+// @Description Void a game summary that has not been completed
+// @Tags game-summaries
+// @Produce json
+// @Param gameSummaryId path string true "Game Summary ID"
+// @Success 200 {object} models.GameSummaryResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /game-summaries/{gameSummaryId}/void [post]
+func (gsc *GameSummaryController) VoidGameSummary(ctx *gin.Context) {
+	gsc.transitionGameSummary(ctx, "", models.GameSummaryStatusVoided)
+}
+
+// transitionGameSummary moves a game summary to the target status. When from is
+// set the session must currently be in that status, otherwise any transition
+// allowed by the lifecycle is accepted.
+func (gsc *GameSummaryController) transitionGameSummary(ctx *gin.Context, from string, to string) {
+	gameSummaryId, err := uuid.Parse(ctx.Param("gameSummaryId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid game summary ID"})
+		return
+	}
+
+	gameSummary, ok := gsc.findScopedGameSummary(ctx, gameSummaryId)
+	if !ok {
+		return
+	}
+
+	if (from != "" && gameSummary.Status != from) || !gameSummary.CanTransitionTo(to) {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": fmt.Sprintf("Cannot move a game summary from %s to %s", gameSummary.Status, to)})
+		return
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{"status": to, "updated_at": now}
+	if to == models.GameSummaryStatusCompleted {
+		updates["end_time"] = now
+	}
+
+	errStatusChanged := errors.New("game summary status changed")
+	err = gsc.DB.Transaction(func(tx *gorm.DB) error {
+		// Guard on the current status so concurrent transitions cannot both succeed
+		result := tx.Model(&models.GameSummary{}).Where("id = ? AND status = ?", gameSummaryId, gameSummary.Status).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errStatusChanged
+		}
+		var after models.GameSummary
+		if err := tx.First(&after, "id = ?", gameSummaryId).Error; err != nil {
+			return err
+		}
+		if err := recordAudit(tx, ctx, models.AuditActionUpdate, "game_summary", gameSummaryId, gameSummary, after); err != nil {
+			return err
+		}
+		if to == models.GameSummaryStatusCompleted || to == models.GameSummaryStatusVoided {
+			return RefreshDealerPerformance(tx, gameSummary.DealerID)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, errStatusChanged) {
+			ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Game summary status changed, please retry"})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to update game summary status"})
+		}
+		return
+	}
+
+	response, err := gsc.getGameSummaryResponse(gameSummaryId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch updated game summary"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": response})
+}
+
+// findScopedGameSummary loads a game summary from the current user's casinos,
+// responding with 404 when there is none.
+func (gsc *GameSummaryController) findScopedGameSummary(ctx *gin.Context, id uuid.UUID) (models.GameSummary, bool) {
+	var gameSummary models.GameSummary
+	if err := scopeToCasinos(ctx, gsc.DB, "casino_id").First(&gameSummary, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No game summary with that ID exists"})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch game summary"})
+		}
+		return models.GameSummary{}, false
+	}
+	return gameSummary, true
+}
+
 func (gsc *GameSummaryController) createGameSummaryFromPayload(payload models.CreateGameSummaryRequest) (models.GameSummary, error) {
 	gameID, err := uuid.Parse(payload.GameID)
 	if err != nil {
@@ -234,30 +473,201 @@ func (gsc *GameSummaryController) createGameSummaryFromPayload(payload models.Cr
 		return models.GameSummary{}, errors.New("invalid casino ID")
 	}
 
+	var casino models.Casino
+	if err := gsc.DB.First(&casino, "id = ?", casinoID).Error; err != nil {
+		return models.GameSummary{}, errors.New("no casino with that ID exists")
+	}
+
 	dealerID, err := uuid.Parse(payload.DealerID)
 	if err != nil {
 		return models.GameSummary{}, errors.New("invalid dealer ID")
 	}
 
+	var tableID *uuid.UUID
+	if payload.TableID != "" {
+		table, err := gsc.findOpenTable(payload.TableID, casinoID, gameID)
+		if err != nil {
+			return models.GameSummary{}, err
+		}
+		tableID = &table.ID
+	}
+
+	status := models.GameSummaryStatusInProgress
+	if payload.StartTime.After(time.Now()) {
+		status = models.GameSummaryStatusScheduled
+	}
+
 	return models.GameSummary{
 		ID:           uuid.New(),
 		GameID:       gameID,
 		CasinoID:     casinoID,
+		TableID:      tableID,
+		Currency:     casino.Currency,
 		StartTime:    payload.StartTime,
 		DealerID:     dealerID,
-		Status:       "In Progress",
+		Status:       status,
 		RoundsPlayed: 0,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}, nil
 }
 
+// findOpenTable checks that a session can run on the table: it must be open and
+// belong to the session's casino and game.
+func (gsc *GameSummaryController) findOpenTable(tableID string, casinoID, gameID uuid.UUID) (models.Table, error) {
+	parsedTableID, err := uuid.Parse(tableID)
+	if err != nil {
+		return models.Table{}, errors.New("invalid table ID")
+	}
+
+	var table models.Table
+	if err := gsc.DB.First(&table, "id = ? AND casino_id = ?", parsedTableID, casinoID).Error; err != nil {
+		return models.Table{}, errors.New("no table with that ID exists in this casino")
+	}
+	if table.GameID != gameID {
+		return models.Table{}, errors.New("table is not set up for this game")
+	}
+	if table.Status != models.TableStatusOpen {
+		return models.Table{}, fmt.Errorf("table is %s", table.Status)
+	}
+
+	return table, nil
+}
+
+// FindDeletedGameSummaries godoc
+// @Summary List deleted game summaries
+// @Description Retrieve deleted game summaries, most recently deleted first. Their transactions are listed by /admin/deleted/transactions.
+// @Tags admin
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Success 200 {array} models.GameSummaryResponse
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/deleted/game-summaries [get]
+func (gsc *GameSummaryController) FindDeletedGameSummaries(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	offset := (page - 1) * limit
+
+	query := scopeToCasinos(ctx, onlyDeleted(gsc.DB.Model(&models.GameSummary{}), "game_summaries"), "casino_id")
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to count deleted game summaries"})
+		return
+	}
+
+	var gameSummaries []models.GameSummary
+	if err := query.Preload("Dealer").Preload("Table").Preload("Players", withDeleted).
+		Order("deleted_at DESC").Limit(limit).Offset(offset).Find(&gameSummaries).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch deleted game summaries"})
+		return
+	}
+
+	responses := make([]models.GameSummaryResponse, len(gameSummaries))
+	for i, gameSummary := range gameSummaries {
+		response, err := gsc.buildGameSummaryResponse(gameSummary)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch deleted game summaries"})
+			return
+		}
+		responses[i] = response
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "results": len(responses), "total": total, "page": page, "limit": limit, "data": responses})
+}
+
+// RestoreGameSummary godoc
+// @Summary Restore a deleted game summary
+// @Description Bring back a deleted game summary with the transactions that were deleted along with it, reapplying them to the players' winnings
+// @Tags admin
+// @Produce json
+// @Param gameSummaryId path string true "Game Summary ID"
+// @Success 200 {object} models.GameSummaryResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/deleted/game-summaries/{gameSummaryId}/restore [post]
+func (gsc *GameSummaryController) RestoreGameSummary(ctx *gin.Context) {
+	gameSummaryId, err := uuid.Parse(ctx.Param("gameSummaryId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid game summary ID"})
+		return
+	}
+
+	var gameSummary models.GameSummary
+	if err := scopeToCasinos(ctx, onlyDeleted(gsc.DB, "game_summaries"), "casino_id").First(&gameSummary, "id = ?", gameSummaryId).Error; err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No deleted game summary with that ID exists"})
+		return
+	}
+
+	err = gsc.DB.Transaction(func(tx *gorm.DB) error {
+		// Transactions deleted on their own before the session stay deleted
+		var transactions []models.Transaction
+		if err := tx.Unscoped().Where("game_summary_id = ? AND deleted_at = ?", gameSummaryId, gameSummary.DeletedAt.Time).Find(&transactions).Error; err != nil {
+			return err
+		}
+
+		before := gameSummary
+		if err := tx.Unscoped().Model(&gameSummary).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		gameSummary.DeletedAt = gorm.DeletedAt{}
+		if err := recordAudit(tx, ctx, models.AuditActionRestore, "game_summary", gameSummary.ID, before, gameSummary); err != nil {
+			return err
+		}
+
+		for _, transaction := range transactions {
+			before := transaction
+			if err := tx.Unscoped().Model(&transaction).Update("deleted_at", nil).Error; err != nil {
+				return err
+			}
+			transaction.DeletedAt = gorm.DeletedAt{}
+			if err := recordAudit(tx, ctx, models.AuditActionRestore, "transaction", transaction.ID, before, transaction); err != nil {
+				return err
+			}
+			if err := adjustPlayerWinnings(tx, transaction.PlayerID, transaction.Amount, gameSummary); err != nil {
+				return err
+			}
+		}
+
+		if err := recalculateGameSummaryTotals(tx, gameSummaryId); err != nil {
+			return err
+		}
+		return RefreshDealerPerformance(tx, gameSummary.DealerID)
+	})
+	if err != nil {
+		if respondMissingRate(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to restore game summary"})
+		return
+	}
+
+	response, err := gsc.getGameSummaryResponse(gameSummaryId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch restored game summary"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": response})
+}
+
+// errUnknownPlayer is returned for a player that does not exist or was deleted.
+var errUnknownPlayer = errors.New("no player with that ID exists")
+
 func (gsc *GameSummaryController) addPlayersToGameSummary(tx *gorm.DB, gameSummaryID uuid.UUID, playerIDs []string) error {
 	for _, playerIDStr := range playerIDs {
 		playerID, err := uuid.Parse(playerIDStr)
 		if err != nil {
 			return errors.New("invalid player ID")
 		}
+		var player models.Player
+		if err := tx.Select("id").First(&player, "id = ?", playerID).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+			return errUnknownPlayer
+		} else if err != nil {
+			return err
+		}
 		if err := tx.Exec("INSERT INTO game_players (game_summary_id, player_id) VALUES (?, ?)", gameSummaryID, playerID).Error; err != nil {
 			return err
 		}
@@ -265,12 +675,71 @@ func (gsc *GameSummaryController) addPlayersToGameSummary(tx *gorm.DB, gameSumma
 	return nil
 }
 
+// recalculateGameSummaryTotals rebuilds the pot, highest bet and rounds played of a
+// game summary from its transactions. It must run inside the same DB transaction
+// as the change that invalidated the totals. Corrected transactions count with
+// their corrected figures only.
+//
+// The game summary row is locked first, so concurrent changes to the same
+// session recalculate one after the other and each sees the rows committed by
+// the ones before it. NO KEY UPDATE is enough for that and, unlike FOR UPDATE,
+// does not deadlock with the key share lock a new transaction row takes on its
+// session.
+func recalculateGameSummaryTotals(tx *gorm.DB, gameSummaryID uuid.UUID) error {
+	var locked models.GameSummary
+	if err := tx.Unscoped().Clauses(clause.Locking{Strength: "NO KEY UPDATE"}).Select("id").
+		First(&locked, "id = ?", gameSummaryID).Error; err != nil {
+		return err
+	}
+
+	var totals struct {
+		TotalPot     models.Money
+		HighestBet   models.Money
+		RoundsPlayed int
+	}
+
+	// Sessions that record rounds count them directly. Older sessions fall back
+	// to the busiest player's transaction count, as every player settles once
+	// per round.
+	if err := tx.Raw(`
+		SELECT
+			COALESCE(SUM(ABS(amount)), 0) AS total_pot,
+			COALESCE(MAX(ABS(amount)), 0) AS highest_bet,
+			COALESCE(
+				NULLIF((SELECT COUNT(*) FROM rounds WHERE game_summary_id = @id), 0),
+				(
+					SELECT MAX(player_rounds) FROM (
+						SELECT COUNT(*) AS player_rounds FROM transactions
+						WHERE game_summary_id = @id AND deleted_at IS NULL AND `+notReversed("transactions")+`
+						GROUP BY player_id
+					) AS per_player
+				),
+				0
+			) AS rounds_played
+		FROM transactions
+		WHERE game_summary_id = @id AND deleted_at IS NULL AND `+notReversed("transactions"), sql.Named("id", gameSummaryID)).Scan(&totals).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&models.GameSummary{}).Where("id = ?", gameSummaryID).Updates(map[string]interface{}{
+		"total_pot":     totals.TotalPot,
+		"highest_bet":   totals.HighestBet,
+		"rounds_played": totals.RoundsPlayed,
+	}).Error
+}
+
 func (gsc *GameSummaryController) getGameSummaryResponse(id uuid.UUID) (models.GameSummaryResponse, error) {
 	var gameSummary models.GameSummary
-	if err := gsc.DB.Preload("Dealer").Preload("Players").Preload("Transactions.Player").First(&gameSummary, id).Error; err != nil {
+	if err := gsc.DB.Preload("Dealer").Preload("Table").Preload("Players", withDeleted).Preload("Transactions.Player", withDeleted).First(&gameSummary, id).Error; err != nil {
 		return models.GameSummaryResponse{}, err
 	}
 
+	return gsc.buildGameSummaryResponse(gameSummary)
+}
+
+// buildGameSummaryResponse converts a game summary with its associations
+// loaded, looking up its game and casino.
+func (gsc *GameSummaryController) buildGameSummaryResponse(gameSummary models.GameSummary) (models.GameSummaryResponse, error) {
 	var game models.Game
 	if err := gsc.DB.First(&game, gameSummary.GameID).Error; err != nil {
 		return models.GameSummaryResponse{}, err
@@ -297,10 +766,16 @@ func (gsc *GameSummaryController) getMultipleGameSummaryResponses(gameSummaries
 }
 
 func convertToGameSummaryResponse(gameSummary models.GameSummary, game models.Game, casino models.Casino) models.GameSummaryResponse {
-	return models.GameSummaryResponse{
+	var table *models.TableResponse
+	if gameSummary.Table != nil {
+		table = &models.TableResponse{ID: gameSummary.Table.ID, TableNumber: gameSummary.Table.TableNumber}
+	}
+
+	response := models.GameSummaryResponse{
 		ID:           gameSummary.ID,
 		Game:         models.GameResponse{ID: game.ID, Name: game.Name},
-		Casino:       models.CasinoResponse{ID: casino.ID, Name: casino.Name},
+		Casino:       models.CasinoResponse{ID: casino.ID, Name: casino.Name, Currency: casino.Currency},
+		Table:        table,
 		StartTime:    gameSummary.StartTime,
 		EndTime:      gameSummary.EndTime,
 		Players:      convertToPlayerResponses(gameSummary.Players),
@@ -309,10 +784,15 @@ func convertToGameSummaryResponse(gameSummary models.GameSummary, game models.Ga
 		Status:       gameSummary.Status,
 		RoundsPlayed: gameSummary.RoundsPlayed,
 		HighestBet:   gameSummary.HighestBet,
+		Currency:     gameSummary.Currency,
 		Transactions: convertToTransactionResponses(gameSummary.Transactions),
 		CreatedAt:    gameSummary.CreatedAt,
 		UpdatedAt:    gameSummary.UpdatedAt,
 	}
+	if gameSummary.DeletedAt.Valid {
+		response.DeletedAt = &gameSummary.DeletedAt.Time
+	}
+	return response
 }
 
 func convertToPlayerResponses(players []models.Player) []models.PlayerResponse {
@@ -322,6 +802,7 @@ func convertToPlayerResponses(players []models.Player) []models.PlayerResponse {
 			ID:            player.ID,
 			Nickname:      player.Nickname,
 			TotalWinnings: player.TotalWinnings,
+			Currency:      models.DefaultCurrency,
 			Rank:          player.Rank,
 			Status:        player.Status,
 			CreatedAt:     player.CreatedAt,
@@ -348,12 +829,17 @@ func convertToTransactionResponses(transactions []models.Transaction) []models.T
 	responses := make([]models.TransactionResponse, len(transactions))
 	for i, transaction := range transactions {
 		responses[i] = models.TransactionResponse{
-			ID:        transaction.ID,
-			Player:    convertToPlayerResponse(transaction.Player),
-			Amount:    transaction.Amount,
-			Outcome:   transaction.Outcome,
-			CreatedAt: transaction.CreatedAt,
-			UpdatedAt: transaction.UpdatedAt,
+			ID:         transaction.ID,
+			Player:     convertToPlayerResponse(transaction.Player),
+			RoundID:    transaction.RoundID,
+			Amount:     transaction.Amount,
+			Currency:   transaction.Currency,
+			Outcome:    transaction.Outcome,
+			ReversesID: transaction.ReversesID,
+			CorrectsID: transaction.CorrectsID,
+			Reason:     transaction.Reason,
+			CreatedAt:  transaction.CreatedAt,
+			UpdatedAt:  transaction.UpdatedAt,
 		}
 	}
 	return responses
@@ -364,6 +850,7 @@ func convertToPlayerResponse(player models.Player) models.PlayerResponse {
 		ID:            player.ID,
 		Nickname:      player.Nickname,
 		TotalWinnings: player.TotalWinnings,
+		Currency:      models.DefaultCurrency,
 		Rank:          player.Rank,
 		Status:        player.Status,
 		CreatedAt:     player.CreatedAt,
@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/suidevv/tableye-api/initializers"
+	"github.com/suidevv/tableye-api/models"
+	"gorm.io/gorm"
+)
+
+func accountLimitKey(email string) string {
+	return "account:" + email
+}
+
+func ipLimitKey(ip string) string {
+	return "ip:" + ip
+}
+
+// signInBlocked returns how long the email address or client IP must wait
+// before trying to sign in again. Limiter errors are logged and let the
+// attempt through so an unreachable shared backend does not stop all logins.
+func signInBlocked(config initializers.Config, email, ip string) time.Duration {
+	accountWait, err := config.AccountLimiter().Blocked(accountLimitKey(email))
+	if err != nil {
+		log.Printf("Failed to check sign-in limit for %s: %v", email, err)
+	}
+
+	ipWait, err := config.IPLimiter().Blocked(ipLimitKey(ip))
+	if err != nil {
+		log.Printf("Failed to check sign-in limit for %s: %v", ip, err)
+	}
+
+	return max(accountWait, ipWait)
+}
+
+// recordFailedSignIn counts a failed attempt against the email address and the
+// client IP, and records a security event for every block it causes. user is
+// nil when no user has the email address.
+func recordFailedSignIn(db *gorm.DB, config initializers.Config, email, ip string, user *models.User) {
+	now := time.Now()
+
+	newEvent := func(eventType string, delay time.Duration) models.SecurityEvent {
+		lockedUntil := now.Add(delay)
+		event := models.SecurityEvent{Type: eventType, Email: email, ClientIP: ip, LockedUntil: &lockedUntil, CreatedAt: now}
+		if user != nil {
+			event.UserID = &user.ID
+		}
+		return event
+	}
+
+	if delay, err := config.AccountLimiter().Fail(accountLimitKey(email)); err != nil {
+		log.Printf("Failed to record failed sign-in for %s: %v", email, err)
+	} else if delay > 0 {
+		event := newEvent(models.SecurityEventAccountLocked, delay)
+		if err := db.Create(&event).Error; err != nil {
+			log.Printf("Failed to record lockout of %s: %v", email, err)
+		}
+	}
+
+	if delay, err := config.IPLimiter().Fail(ipLimitKey(ip)); err != nil {
+		log.Printf("Failed to record failed sign-in for %s: %v", ip, err)
+	} else if delay > 0 {
+		event := newEvent(models.SecurityEventIPBlocked, delay)
+		if err := db.Create(&event).Error; err != nil {
+			log.Printf("Failed to record block of %s: %v", ip, err)
+		}
+	}
+}
+
+// mailRequestBlocked counts a request to mail a link to email against the
+// address and the client IP, and returns how long they must wait when they
+// asked too often. Mail requests use the sign-in limiters under their own
+// keys, so they do not eat into the allowance for sign-ins. Limiter errors are
+// logged and let the request through.
+func mailRequestBlocked(config initializers.Config, email, ip string) time.Duration {
+	accountKey, ipKey := "mail:"+accountLimitKey(email), "mail:"+ipLimitKey(ip)
+
+	accountWait, err := config.AccountLimiter().Blocked(accountKey)
+	if err != nil {
+		log.Printf("Failed to check mail limit for %s: %v", email, err)
+	}
+	ipWait, err := config.IPLimiter().Blocked(ipKey)
+	if err != nil {
+		log.Printf("Failed to check mail limit for %s: %v", ip, err)
+	}
+	if wait := max(accountWait, ipWait); wait > 0 {
+		return wait
+	}
+
+	if _, err := config.AccountLimiter().Fail(accountKey); err != nil {
+		log.Printf("Failed to record mail request for %s: %v", email, err)
+	}
+	if _, err := config.IPLimiter().Fail(ipKey); err != nil {
+		log.Printf("Failed to record mail request for %s: %v", ip, err)
+	}
+	return 0
+}
+
+func respondSignInBlocked(ctx *gin.Context, wait time.Duration) {
+	respondTooManyRequests(ctx, wait, "failed sign-in attempts")
+}
+
+func respondTooManyRequests(ctx *gin.Context, wait time.Duration, what string) {
+	seconds := int(math.Ceil(wait.Seconds()))
+	ctx.Header("Retry-After", strconv.Itoa(seconds))
+	ctx.JSON(http.StatusTooManyRequests, gin.H{
+		"status":  "fail",
+		"message": fmt.Sprintf("Too many %s, try again in %s", what, (time.Duration(seconds) * time.Second).String()),
+	})
+}
@@ -0,0 +1,208 @@
+// controllers/permission.controller.go
+
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/suidevv/tableye-api/models"
+	"gorm.io/gorm"
+)
+
+type PermissionController struct {
+	DB *gorm.DB
+}
+
+func NewPermissionController(DB *gorm.DB) PermissionController {
+	return PermissionController{DB}
+}
+
+// FindPermissions godoc
+// @Summary List permissions
+// @Description Returns every permission together with the roles it is granted to
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.PermissionResponse
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/permissions [get]
+func (pc *PermissionController) FindPermissions(ctx *gin.Context) {
+	var permissions []models.Permission
+	if err := pc.DB.Order("name").Find(&permissions).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch permissions"})
+		return
+	}
+
+	var grants []models.RolePermission
+	if err := pc.DB.Order("role").Find(&grants).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch role permissions"})
+		return
+	}
+
+	roles := make(map[string][]string)
+	for _, grant := range grants {
+		roles[grant.Permission] = append(roles[grant.Permission], grant.Role)
+	}
+
+	responses := make([]models.PermissionResponse, len(permissions))
+	for i, permission := range permissions {
+		responses[i] = models.PermissionResponse{
+			Name:        permission.Name,
+			Description: permission.Description,
+			Roles:       roles[permission.Name],
+		}
+		if responses[i].Roles == nil {
+			responses[i].Roles = []string{}
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "results": len(responses), "data": responses})
+}
+
+// FindRolePermissions godoc
+// @Summary List the permissions of a role
+// @Description Returns the permissions granted to a role
+// @Tags admin
+// @Produce json
+// @Param role path string true "Role"
+// @Security BearerAuth
+// @Success 200 {array} string
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/roles/{role}/permissions [get]
+func (pc *PermissionController) FindRolePermissions(ctx *gin.Context) {
+	role := ctx.Param("role")
+	if !models.IsValidRole(role) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Unknown role"})
+		return
+	}
+
+	permissions, err := findRolePermissions(pc.DB, role)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch role permissions"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "results": len(permissions), "data": permissions})
+}
+
+// GrantRolePermission godoc
+// @Summary Grant a permission to a role
+// @Description Grants a permission to every user with the role
+// @Tags admin
+// @Produce json
+// @Param role path string true "Role"
+// @Param permission path string true "Permission"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/roles/{role}/permissions/{permission} [post]
+func (pc *PermissionController) GrantRolePermission(ctx *gin.Context) {
+	role, permission, ok := pc.findRoleAndPermission(ctx)
+	if !ok {
+		return
+	}
+
+	grant := gin.H{"role": role, "permission": permission.Name}
+	var inserted int64
+	err := pc.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec("INSERT INTO role_permissions (role, permission) VALUES (?, ?) ON CONFLICT DO NOTHING", role, permission.Name)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		inserted = result.RowsAffected
+		return recordAudit(tx, ctx, models.AuditActionCreate, "role_permission", role+":"+permission.Name, nil, grant)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to grant permission"})
+		return
+	}
+	if inserted == 0 {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Role already has this permission"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"status": "success", "data": grant})
+}
+
+// RevokeRolePermission godoc
+// @Summary Revoke a permission from a role
+// @Description Revokes a permission from every user with the role. Admins cannot lose the permission to manage permissions.
+// @Tags admin
+// @Produce json
+// @Param role path string true "Role"
+// @Param permission path string true "Permission"
+// @Security BearerAuth
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/roles/{role}/permissions/{permission} [delete]
+func (pc *PermissionController) RevokeRolePermission(ctx *gin.Context) {
+	role, permission, ok := pc.findRoleAndPermission(ctx)
+	if !ok {
+		return
+	}
+
+	// Without this nobody could grant it back
+	if role == models.RoleAdmin && permission.Name == models.PermissionPermissionsManage {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Admins must keep the permission to manage permissions"})
+		return
+	}
+
+	var deleted int64
+	err := pc.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec("DELETE FROM role_permissions WHERE role = ? AND permission = ?", role, permission.Name)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		deleted = result.RowsAffected
+		return recordAudit(tx, ctx, models.AuditActionDelete, "role_permission", role+":"+permission.Name,
+			gin.H{"role": role, "permission": permission.Name}, nil)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to revoke permission"})
+		return
+	}
+	if deleted == 0 {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "Role does not have this permission"})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+func (pc *PermissionController) findRoleAndPermission(ctx *gin.Context) (string, models.Permission, bool) {
+	var permission models.Permission
+
+	role := ctx.Param("role")
+	if !models.IsValidRole(role) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Unknown role"})
+		return role, permission, false
+	}
+
+	if err := pc.DB.First(&permission, "name = ?", ctx.Param("permission")).Error; err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No permission with that name exists"})
+		return role, permission, false
+	}
+
+	return role, permission, true
+}
+
+func findRolePermissions(db *gorm.DB, role string) ([]string, error) {
+	permissions := []string{}
+	err := db.Model(&models.RolePermission{}).
+		Where("role = ?", role).
+		Order("permission").
+		Pluck("permission", &permissions).Error
+	return permissions, err
+}
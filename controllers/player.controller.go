@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/suidevv/tableye-api/models"
 	"gorm.io/gorm"
 )
@@ -27,7 +28,7 @@ func NewPlayerController(DB *gorm.DB) PlayerController {
 //	@Accept			json
 //	@Produce		json
 //	@Param			player	body		models.CreatePlayerRequest	true	"Create player request"
-//	@Success		201		{object}	models.Player
+//	@Success		201		{object}	models.PlayerResponse
 //	@Failure		400		{object}	map[string]interface{}
 //	@Failure		409		{object}	map[string]interface{}
 //	@Failure		502		{object}	map[string]interface{}
@@ -49,17 +50,22 @@ func (pc *PlayerController) CreatePlayer(ctx *gin.Context) {
 		UpdatedAt:     now,
 	}
 
-	result := pc.DB.Create(&newPlayer)
-	if result.Error != nil {
-		if strings.Contains(result.Error.Error(), "duplicate key") {
+	err := pc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&newPlayer).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, ctx, models.AuditActionCreate, "player", newPlayer.ID, nil, newPlayer)
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
 			ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Player with that nickname already exists"})
 			return
 		}
-		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": result.Error.Error()})
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, gin.H{"status": "success", "data": newPlayer})
+	ctx.JSON(http.StatusCreated, gin.H{"status": "success", "data": convertToPlayerResponse(newPlayer)})
 }
 
 // UpdatePlayer godoc
@@ -71,7 +77,7 @@ func (pc *PlayerController) CreatePlayer(ctx *gin.Context) {
 //	@Produce		json
 //	@Param			playerId	path		string						true	"Player ID"
 //	@Param			player		body		models.UpdatePlayerRequest	true	"Update player request"
-//	@Success		200			{object}	models.Player
+//	@Success		200			{object}	models.PlayerResponse
 //	@Failure		400			{object}	map[string]interface{}
 //	@Failure		404			{object}	map[string]interface{}
 //	@Router			/players/{playerId} [put]
@@ -93,15 +99,25 @@ func (pc *PlayerController) UpdatePlayer(ctx *gin.Context) {
 
 	now := time.Now()
 	playerToUpdate := models.Player{
-		Nickname:      payload.Nickname,
-		TotalWinnings: payload.TotalWinnings,
-		Rank:          payload.Rank,
-		Status:        payload.Status,
-		UpdatedAt:     now,
+		Nickname:  payload.Nickname,
+		Rank:      payload.Rank,
+		Status:    payload.Status,
+		UpdatedAt: now,
 	}
 
-	pc.DB.Model(&player).Updates(playerToUpdate)
-	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": player})
+	before := player
+	err := pc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&player).Updates(playerToUpdate).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, ctx, models.AuditActionUpdate, "player", player.ID, before, player)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Failed to update player"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": convertToPlayerResponse(player)})
 }
 
 // FindPlayerById godoc
@@ -112,7 +128,7 @@ func (pc *PlayerController) UpdatePlayer(ctx *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			playerId	path		string	true	"Player ID"
-//	@Success		200			{object}	models.Player
+//	@Success		200			{object}	models.PlayerResponse
 //	@Failure		404			{object}	map[string]interface{}
 //	@Router			/players/{playerId} [get]
 func (pc *PlayerController) FindPlayerById(ctx *gin.Context) {
@@ -125,7 +141,7 @@ func (pc *PlayerController) FindPlayerById(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": player})
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": convertToPlayerResponse(player)})
 }
 
 // FindPlayers godoc
@@ -155,13 +171,13 @@ func (pc *PlayerController) FindPlayers(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"status": "success", "results": len(players), "data": players})
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "results": len(players), "data": convertToPlayerResponses(players)})
 }
 
 // DeletePlayer godoc
 //
 //	@Summary		Delete a player
-//	@Description	Delete a player by ID
+//	@Description	Delete a player by ID. The player and their transactions are kept for bookkeeping and an admin can restore them.
 //	@Tags			players
 //	@Accept			json
 //	@Produce		json
@@ -179,24 +195,118 @@ func (pc *PlayerController) DeletePlayer(ctx *gin.Context) {
 		return
 	}
 
-	pc.DB.Delete(&player)
+	err := pc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&player).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, ctx, models.AuditActionDelete, "player", player.ID, player, nil)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Failed to delete player"})
+		return
+	}
+
 	ctx.JSON(http.StatusNoContent, nil)
 }
 
+// FindDeletedPlayers godoc
+//
+//	@Summary		List deleted players
+//	@Description	Get deleted players, most recently deleted first
+//	@Tags			admin
+//	@Produce		json
+//	@Param			page	query		int	false	"Page number"				default(1)
+//	@Param			limit	query		int	false	"Number of items per page"	default(10)
+//	@Success		200		{object}	map[string]interface{}
+//	@Failure		500		{object}	map[string]interface{}
+//	@Router			/admin/deleted/players [get]
+func (pc *PlayerController) FindDeletedPlayers(ctx *gin.Context) {
+	intPage, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	intLimit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	offset := (intPage - 1) * intLimit
+
+	query := onlyDeleted(pc.DB.Model(&models.Player{}), "players")
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to count deleted players"})
+		return
+	}
+
+	var players []models.Player
+	if err := query.Order("deleted_at DESC").Limit(intLimit).Offset(offset).Find(&players).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch deleted players"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"results": len(players),
+		"total":   total,
+		"page":    intPage,
+		"limit":   intLimit,
+		"data":    players,
+	})
+}
+
+// RestorePlayer godoc
+//
+//	@Summary		Restore a deleted player
+//	@Description	Bring back a deleted player
+//	@Tags			admin
+//	@Produce		json
+//	@Param			playerId	path		string	true	"Player ID"
+//	@Success		200			{object}	models.PlayerResponse
+//	@Failure		404			{object}	map[string]interface{}
+//	@Failure		500			{object}	map[string]interface{}
+//	@Router			/admin/deleted/players/{playerId}/restore [post]
+func (pc *PlayerController) RestorePlayer(ctx *gin.Context) {
+	var player models.Player
+	if err := onlyDeleted(pc.DB, "players").First(&player, "id = ?", ctx.Param("playerId")).Error; err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No deleted player with that ID exists"})
+		return
+	}
+
+	before := player
+	err := pc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&player).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		player.DeletedAt = gorm.DeletedAt{}
+		return recordAudit(tx, ctx, models.AuditActionRestore, "player", player.ID, before, player)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to restore player"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": convertToPlayerResponse(player)})
+}
+
 // FindPlayerStats godoc
 //
 //	@Summary		Get player statistics
-//	@Description	Get statistics of a player by ID
+//	@Description	Get statistics of a player by ID computed from their sessions and transactions, with a per-game breakdown. Amounts are converted to the reporting currency.
 //	@Tags			players
 //	@Accept			json
 //	@Produce		json
 //	@Param			playerId	path		string	true	"Player ID"
-//	@Success		200			{object}	map[string]interface{}
+//	@Param			from		query		string	false	"Only include sessions started from this date (YYYY-MM-DD or RFC 3339)"
+//	@Param			to			query		string	false	"Only include sessions started before the end of this date (YYYY-MM-DD or RFC 3339)"
+//	@Success		200			{object}	models.PlayerStatsResponse
+//	@Failure		400			{object}	map[string]interface{}
 //	@Failure		404			{object}	map[string]interface{}
+//	@Failure		500			{object}	map[string]interface{}
 //	@Router			/players/{playerId}/stats [get]
 func (pc *PlayerController) FindPlayerStats(ctx *gin.Context) {
 	playerId := ctx.Param("playerId")
 
+	from, to, err := parseTimeRange(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
 	var player models.Player
 	result := pc.DB.First(&player, "id = ?", playerId)
 	if result.Error != nil {
@@ -204,13 +314,162 @@ func (pc *PlayerController) FindPlayerStats(ctx *gin.Context) {
 		return
 	}
 
-	winRate := float64(0)
-
-	stats := gin.H{
-		"total_winnings": player.TotalWinnings,
-		"win_rate":       winRate,
-		"rank":           player.Rank,
+	stats, err := pc.computePlayerStats(player.ID, from, to)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to compute player statistics"})
+		return
 	}
 
+	stats.TotalWinnings = player.TotalWinnings
+	stats.Rank = player.Rank
+	stats.From = from
+	stats.To = to
+
 	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": stats})
 }
+
+// playerBetting holds the transaction aggregates shared by the overall and
+// per-game statistics. GameID is only set for the per-game rows.
+type playerBetting struct {
+	GameID       uuid.UUID
+	RoundsPlayed int64
+	Wins         int64
+	Losses       int64
+	NetResult    models.Money
+	AverageBet   models.Money
+	LargestBet   models.Money
+}
+
+// playerAmount is a transaction amount in the reporting currency, converted the
+// same way as the player's running total.
+var playerAmount = "ROUND(t.amount * " + models.ConversionRateSQL("t.currency", "gs.start_time", models.DefaultCurrency) + ", 2)"
+
+var playerBettingColumns = `
+	COUNT(DISTINCT COALESCE(t.round_id, t.id)) AS rounds_played,
+	COUNT(*) FILTER (WHERE t.outcome = 'win') AS wins,
+	COUNT(*) FILTER (WHERE t.outcome = 'loss') AS losses,
+	COALESCE(SUM(` + playerAmount + `), 0) AS net_result,
+	COALESCE(AVG(ABS(` + playerAmount + `)), 0) AS average_bet,
+	COALESCE(MAX(ABS(` + playerAmount + `)), 0) AS largest_bet`
+
+func (pc *PlayerController) computePlayerStats(playerID uuid.UUID, from, to *time.Time) (models.PlayerStatsResponse, error) {
+	stats := models.PlayerStatsResponse{PlayerID: playerID, Currency: models.DefaultCurrency, Games: []models.PlayerGameStats{}}
+
+	var overall playerBetting
+	if err := pc.playerTransactions(playerID, from, to).Select(playerBettingColumns).Scan(&overall).Error; err != nil {
+		return stats, err
+	}
+	stats.RoundsPlayed = overall.RoundsPlayed
+	stats.Wins = overall.Wins
+	stats.Losses = overall.Losses
+	stats.WinRate = winRate(overall.Wins, overall.Losses)
+	stats.NetResult = overall.NetResult
+	stats.AverageBet = overall.AverageBet
+	stats.LargestBet = overall.LargestBet
+
+	var sessionsPlayed int64
+	if err := pc.playerSessions(playerID, from, to).Count(&sessionsPlayed).Error; err != nil {
+		return stats, err
+	}
+	stats.SessionsPlayed = sessionsPlayed
+
+	var gameSessions []models.PlayerFavourite
+	if err := pc.playerSessions(playerID, from, to).
+		Select("g.id, g.name, COUNT(*) AS sessions_played").
+		Joins("JOIN games g ON g.id = gs.game_id").
+		Group("g.id, g.name").
+		Order("sessions_played DESC, g.name").
+		Scan(&gameSessions).Error; err != nil {
+		return stats, err
+	}
+
+	var casinoSessions []models.PlayerFavourite
+	if err := pc.playerSessions(playerID, from, to).
+		Select("c.id, c.name, COUNT(*) AS sessions_played").
+		Joins("JOIN casinos c ON c.id = gs.casino_id").
+		Group("c.id, c.name").
+		Order("sessions_played DESC, c.name").
+		Scan(&casinoSessions).Error; err != nil {
+		return stats, err
+	}
+
+	var gameBetting []playerBetting
+	if err := pc.playerTransactions(playerID, from, to).
+		Select("gs.game_id, " + playerBettingColumns).
+		Group("gs.game_id").
+		Scan(&gameBetting).Error; err != nil {
+		return stats, err
+	}
+
+	bettingByGame := make(map[uuid.UUID]playerBetting, len(gameBetting))
+	for _, row := range gameBetting {
+		bettingByGame[row.GameID] = row
+	}
+
+	// Sessions include every session the player has transactions in, so the
+	// breakdown covers all of the player's betting and adds up to the totals.
+	for _, game := range gameSessions {
+		betting := bettingByGame[game.ID]
+		stats.Games = append(stats.Games, models.PlayerGameStats{
+			GameID:         game.ID,
+			GameName:       game.Name,
+			SessionsPlayed: game.SessionsPlayed,
+			RoundsPlayed:   betting.RoundsPlayed,
+			Wins:           betting.Wins,
+			Losses:         betting.Losses,
+			WinRate:        winRate(betting.Wins, betting.Losses),
+			NetResult:      betting.NetResult,
+			AverageBet:     betting.AverageBet,
+			LargestBet:     betting.LargestBet,
+		})
+	}
+
+	if len(gameSessions) > 0 {
+		stats.FavouriteGame = &gameSessions[0]
+	}
+	if len(casinoSessions) > 0 {
+		stats.FavouriteCasino = &casinoSessions[0]
+	}
+
+	return stats, nil
+}
+
+// playerSessions selects the game summaries a player sat at or has
+// transactions in, so every game in the breakdown has its sessions counted.
+func (pc *PlayerController) playerSessions(playerID uuid.UUID, from, to *time.Time) *gorm.DB {
+	query := pc.DB.Table("game_summaries gs").
+		Where(`gs.deleted_at IS NULL AND gs.id IN (
+			SELECT game_summary_id FROM game_players WHERE player_id = ?
+			UNION
+			SELECT game_summary_id FROM transactions WHERE player_id = ? AND deleted_at IS NULL)`, playerID, playerID)
+	return filterSessionStart(query, from, to)
+}
+
+// playerTransactions selects a player's transactions joined to their game summary.
+// Reversed transactions and their reversals are left out.
+func (pc *PlayerController) playerTransactions(playerID uuid.UUID, from, to *time.Time) *gorm.DB {
+	query := pc.DB.Table("transactions t").
+		Joins("JOIN game_summaries gs ON gs.id = t.game_summary_id AND gs.deleted_at IS NULL").
+		Where("t.player_id = ? AND t.deleted_at IS NULL AND "+notReversed("t"), playerID)
+	return filterSessionStart(query, from, to)
+}
+
+// filterSessionStart keeps the rows whose session started within the range.
+// Sessions and transactions are both filtered on it, so a session and all of
+// its transactions are either in the statistics or out of them.
+func filterSessionStart(query *gorm.DB, from, to *time.Time) *gorm.DB {
+	if from != nil {
+		query = query.Where("gs.start_time >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("gs.start_time < ?", *to)
+	}
+	return query
+}
+
+func winRate(wins, losses int64) float64 {
+	if wins+losses == 0 {
+		return 0
+	}
+	return float64(wins) / float64(wins+losses)
+}
@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"github.com/suidevv/tableye-api/models"
+	"gorm.io/gorm"
+)
+
+// ledgerAmount is a transaction amount in the reporting currency, converted at
+// the rate of its session's start date like adjustPlayerWinnings does when
+// booking it.
+var ledgerAmount = "ROUND(transactions.amount * " +
+	models.ConversionRateSQL("transactions.currency", "game_summaries.start_time", models.DefaultCurrency) + ", 2)"
+
+// FindWinningsDiscrepancies lists the players, deleted ones included, whose
+// running total no longer matches their transactions.
+func FindWinningsDiscrepancies(db *gorm.DB) ([]models.WinningsDiscrepancy, error) {
+	var discrepancies []models.WinningsDiscrepancy
+	err := db.Raw(`
+		SELECT players.id, players.nickname, players.total_winnings, COALESCE(ledger.total, 0) AS ledger_total
+		FROM players
+		LEFT JOIN (
+			SELECT transactions.player_id, SUM(` + ledgerAmount + `) AS total
+			FROM transactions
+			JOIN game_summaries ON game_summaries.id = transactions.game_summary_id
+			WHERE transactions.deleted_at IS NULL
+			GROUP BY transactions.player_id
+		) AS ledger ON ledger.player_id = players.id
+		WHERE players.total_winnings <> COALESCE(ledger.total, 0)
+		ORDER BY players.nickname`).Scan(&discrepancies).Error
+	return discrepancies, err
+}
+
+// ReconcilePlayerWinnings resets every mismatched running total to the sum of
+// the player's transactions and returns how many players it corrected.
+func ReconcilePlayerWinnings(db *gorm.DB) (int64, error) {
+	result := db.Exec(`
+		UPDATE players SET total_winnings = ledger.total, updated_at = NOW()
+		FROM (
+			SELECT players.id, COALESCE(SUM(` + ledgerAmount + `), 0) AS total
+			FROM players
+			LEFT JOIN transactions ON transactions.player_id = players.id AND transactions.deleted_at IS NULL
+			LEFT JOIN game_summaries ON game_summaries.id = transactions.game_summary_id
+			GROUP BY players.id
+		) AS ledger
+		WHERE players.id = ledger.id AND players.total_winnings <> ledger.total`)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,281 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/suidevv/tableye-api/models"
+	"gorm.io/gorm"
+)
+
+type ReportController struct {
+	DB *gorm.DB
+}
+
+func NewReportController(DB *gorm.DB) ReportController {
+	return ReportController{DB}
+}
+
+var reportPeriods = map[string]bool{"day": true, "week": true, "month": true}
+
+// CasinoRevenue godoc
+//
+//	@Summary		Casino gross gaming revenue
+//	@Description	Gross gaming revenue (negated sum of player outcomes), settled volume (sum of absolute player outcomes) and session count per casino and period. Voided sessions are excluded. Amounts are converted to the reporting currency at the rate of the session start date.
+//	@Tags			reports
+//	@Produce		json
+//	@Param			period		query		string	false	"Grouping period: day, week or month"	default(day)
+//	@Param			casino_id	query		string	false	"Casino ID to filter by"
+//	@Param			currency	query		string	false	"Reporting currency (ISO 4217). Defaults to the casino's currency with casino_id, EUR otherwise"
+//	@Param			from		query		string	false	"Sessions starting from this date (YYYY-MM-DD or RFC 3339)"
+//	@Param			to			query		string	false	"Sessions starting before the end of this date (YYYY-MM-DD or RFC 3339)"
+//	@Success		200			{array}		models.CasinoRevenueReport
+//	@Failure		400			{object}	map[string]interface{}
+//	@Failure		422			{object}	map[string]interface{}
+//	@Failure		500			{object}	map[string]interface{}
+//	@Router			/reports/revenue [get]
+func (rc *ReportController) CasinoRevenue(ctx *gin.Context) {
+	period := ctx.DefaultQuery("period", "day")
+	if !reportPeriods[period] {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Period must be one of day, week or month"})
+		return
+	}
+
+	query, currency, err := rc.reportSessions(ctx)
+	if err != nil {
+		if respondMissingRate(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	// period is whitelisted above, so it is safe to inline
+	periodStart := fmt.Sprintf("date_trunc('%s', gs.start_time)", period)
+
+	var rows []models.CasinoRevenueReport
+	if err := query.
+		Select(`gs.casino_id, c.name AS casino_name, ` + periodStart + ` AS period_start,
+			COUNT(DISTINCT gs.id) AS sessions,
+			COALESCE(ROUND(SUM(ABS(t.amount) * gs.fx), 2), 0) AS settled_volume,
+			COALESCE(ROUND(-SUM(t.amount * gs.fx), 2), 0) AS gross_gaming_revenue`).
+		Joins("JOIN casinos c ON c.id = gs.casino_id").
+		Group("gs.casino_id, c.name, " + periodStart).
+		Order("period_start, c.name").
+		Scan(&rows).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to build revenue report"})
+		return
+	}
+
+	for i := range rows {
+		rows[i].Currency = currency
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "period": period, "currency": currency, "results": len(rows), "data": rows})
+}
+
+// GameResults godoc
+//
+//	@Summary		Results per game
+//	@Description	Settled volume (sum of absolute player outcomes), house win and win percentage (house win over settled volume) per game. Voided sessions are excluded. Amounts are converted to the reporting currency at the rate of the session start date.
+//	@Tags			reports
+//	@Produce		json
+//	@Param			casino_id	query		string	false	"Casino ID to filter by"
+//	@Param			currency	query		string	false	"Reporting currency (ISO 4217). Defaults to the casino's currency with casino_id, EUR otherwise"
+//	@Param			from		query		string	false	"Sessions starting from this date (YYYY-MM-DD or RFC 3339)"
+//	@Param			to			query		string	false	"Sessions starting before the end of this date (YYYY-MM-DD or RFC 3339)"
+//	@Success		200			{array}		models.GameResultReport
+//	@Failure		400			{object}	map[string]interface{}
+//	@Failure		422			{object}	map[string]interface{}
+//	@Failure		500			{object}	map[string]interface{}
+//	@Router			/reports/games [get]
+func (rc *ReportController) GameResults(ctx *gin.Context) {
+	query, currency, err := rc.reportSessions(ctx)
+	if err != nil {
+		if respondMissingRate(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	var rows []models.GameResultReport
+	if err := query.
+		Select(`gs.game_id, g.name AS game_name,
+			COUNT(DISTINCT gs.id) AS sessions,
+			COALESCE(ROUND(SUM(ABS(t.amount) * gs.fx), 2), 0) AS settled_volume,
+			COALESCE(ROUND(-SUM(t.amount * gs.fx), 2), 0) AS house_win`).
+		Joins("JOIN games g ON g.id = gs.game_id").
+		Group("gs.game_id, g.name").
+		Order("g.name").
+		Scan(&rows).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to build game report"})
+		return
+	}
+
+	for i := range rows {
+		rows[i].Currency = currency
+		if rows[i].SettledVolume != 0 {
+			rows[i].WinPercentage = rows[i].HouseWin.Float() / rows[i].SettledVolume.Float() * 100
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "currency": currency, "results": len(rows), "data": rows})
+}
+
+// DealerTables godoc
+//
+//	@Summary		Table performance per dealer
+//	@Description	Session count, settled volume (sum of absolute player outcomes) and house win per dealer. Voided sessions are excluded. Amounts are converted to the reporting currency at the rate of the session start date.
+//	@Tags			reports
+//	@Produce		json
+//	@Param			casino_id	query		string	false	"Casino ID to filter by"
+//	@Param			currency	query		string	false	"Reporting currency (ISO 4217). Defaults to the casino's currency with casino_id, EUR otherwise"
+//	@Param			from		query		string	false	"Sessions starting from this date (YYYY-MM-DD or RFC 3339)"
+//	@Param			to			query		string	false	"Sessions starting before the end of this date (YYYY-MM-DD or RFC 3339)"
+//	@Success		200			{array}		models.DealerTableReport
+//	@Failure		400			{object}	map[string]interface{}
+//	@Failure		422			{object}	map[string]interface{}
+//	@Failure		500			{object}	map[string]interface{}
+//	@Router			/reports/dealers [get]
+func (rc *ReportController) DealerTables(ctx *gin.Context) {
+	query, currency, err := rc.reportSessions(ctx)
+	if err != nil {
+		if respondMissingRate(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	var rows []models.DealerTableReport
+	if err := query.
+		Select(`gs.dealer_id, d.dealer_code,
+			COUNT(DISTINCT gs.id) AS sessions,
+			COALESCE(ROUND(SUM(ABS(t.amount) * gs.fx), 2), 0) AS settled_volume,
+			COALESCE(ROUND(-SUM(t.amount * gs.fx), 2), 0) AS house_win`).
+		Joins("JOIN dealers d ON d.id = gs.dealer_id").
+		Group("gs.dealer_id, d.dealer_code").
+		Order("d.dealer_code").
+		Scan(&rows).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to build dealer report"})
+		return
+	}
+
+	for i := range rows {
+		rows[i].Currency = currency
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "currency": currency, "results": len(rows), "data": rows})
+}
+
+// TableResults godoc
+//
+//	@Summary		Results per table
+//	@Description	Session count, settled volume (sum of absolute player outcomes) and house win per physical table. Sessions not linked to a table and voided sessions are excluded. Amounts are converted to the reporting currency at the rate of the session start date.
+//	@Tags			reports
+//	@Produce		json
+//	@Param			casino_id	query		string	false	"Casino ID to filter by"
+//	@Param			currency	query		string	false	"Reporting currency (ISO 4217). Defaults to the casino's currency with casino_id, EUR otherwise"
+//	@Param			from		query		string	false	"Sessions starting from this date (YYYY-MM-DD or RFC 3339)"
+//	@Param			to			query		string	false	"Sessions starting before the end of this date (YYYY-MM-DD or RFC 3339)"
+//	@Success		200			{array}		models.TableReport
+//	@Failure		400			{object}	map[string]interface{}
+//	@Failure		422			{object}	map[string]interface{}
+//	@Failure		500			{object}	map[string]interface{}
+//	@Router			/reports/tables [get]
+func (rc *ReportController) TableResults(ctx *gin.Context) {
+	query, currency, err := rc.reportSessions(ctx)
+	if err != nil {
+		if respondMissingRate(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	var rows []models.TableReport
+	if err := query.
+		Select(`gs.table_id, tb.table_number, tb.casino_id, c.name AS casino_name,
+			COUNT(DISTINCT gs.id) AS sessions,
+			COALESCE(ROUND(SUM(ABS(t.amount) * gs.fx), 2), 0) AS settled_volume,
+			COALESCE(ROUND(-SUM(t.amount * gs.fx), 2), 0) AS house_win`).
+		Joins("JOIN tables tb ON tb.id = gs.table_id").
+		Joins("JOIN casinos c ON c.id = tb.casino_id").
+		Group("gs.table_id, tb.table_number, tb.casino_id, c.name").
+		Order("c.name, tb.table_number").
+		Scan(&rows).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to build table report"})
+		return
+	}
+
+	for i := range rows {
+		rows[i].Currency = currency
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "currency": currency, "results": len(rows), "data": rows})
+}
+
+// reportSessions selects the non-voided, non-deleted game summaries in the current user's
+// casinos matching the casino and date filters, left joined to their transactions
+// less any reversed ones. Each session carries fx, the factor converting its
+// amounts into the returned reporting currency.
+func (rc *ReportController) reportSessions(ctx *gin.Context) (*gorm.DB, string, error) {
+	from, to, err := parseTimeRange(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	casinoID := ctx.Query("casino_id")
+	if casinoID != "" {
+		if _, err := uuid.Parse(casinoID); err != nil {
+			return nil, "", fmt.Errorf("invalid casino ID")
+		}
+	}
+
+	currency := strings.ToUpper(ctx.Query("currency"))
+	if currency == "" {
+		currency = models.DefaultCurrency
+		var casino models.Casino
+		if casinoID != "" && scopeToCasinos(ctx, rc.DB, "id").First(&casino, "id = ?", casinoID).Error == nil {
+			currency = casino.Currency
+		}
+	} else if !models.IsCurrencyCode(currency) {
+		return nil, "", fmt.Errorf("invalid currency, use an ISO 4217 code such as EUR")
+	}
+
+	sessions := fmt.Sprintf("(SELECT game_summaries.*, %s AS fx FROM game_summaries) gs",
+		models.ConversionRateSQL("game_summaries.currency", "game_summaries.start_time", currency))
+	query := rc.DB.Table(sessions).
+		Joins("LEFT JOIN transactions t ON t.game_summary_id = gs.id AND t.deleted_at IS NULL AND "+notReversed("t")).
+		Where("gs.status <> ? AND gs.deleted_at IS NULL", models.GameSummaryStatusVoided)
+	query = scopeToCasinos(ctx, query, "gs.casino_id")
+
+	if casinoID != "" {
+		query = query.Where("gs.casino_id = ?", casinoID)
+	}
+	if from != nil {
+		query = query.Where("gs.start_time >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("gs.start_time < ?", *to)
+	}
+
+	// A missing rate would silently drop the session from the sums
+	var missing struct {
+		Currency  string
+		StartTime time.Time
+	}
+	if err := query.Session(&gorm.Session{}).Select("gs.currency, gs.start_time").Where("gs.fx IS NULL").Limit(1).Scan(&missing).Error; err != nil {
+		return nil, "", err
+	}
+	if missing.Currency != "" {
+		return nil, "", &missingRateError{From: missing.Currency, To: currency, Day: missing.StartTime}
+	}
+
+	return query, currency, nil
+}
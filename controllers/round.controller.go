@@ -0,0 +1,288 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/suidevv/tableye-api/models"
+	"gorm.io/gorm"
+)
+
+type RoundController struct {
+	DB *gorm.DB
+}
+
+func NewRoundController(DB *gorm.DB) RoundController {
+	return RoundController{DB}
+}
+
+// CreateRound godoc
+// @Summary Record a round
+// @Description Record a hand or spin inside a running game summary
+// @Tags rounds
+// @Accept json
+// @Produce json
+// @Param gameSummaryId path string true "Game Summary ID"
+// @Param payload body models.CreateRoundRequest true "Create round payload"
+// @Success 201 {object} models.RoundResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /game-summaries/{gameSummaryId}/rounds [post]
+func (rc *RoundController) CreateRound(ctx *gin.Context) {
+	gameSummary, ok := rc.findGameSummary(ctx)
+	if !ok {
+		return
+	}
+
+	var payload models.CreateRoundRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	if gameSummary.Status != models.GameSummaryStatusInProgress {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Rounds can only be recorded while a game summary is in progress"})
+		return
+	}
+
+	if len(payload.Outcome) > 0 && !json.Valid(payload.Outcome) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Outcome must be valid JSON"})
+		return
+	}
+
+	dealerID := gameSummary.DealerID
+	if payload.DealerID != "" {
+		parsedDealerID, err := uuid.Parse(payload.DealerID)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid dealer ID"})
+			return
+		}
+		dealerID = parsedDealerID
+
+		// A dealer taking over mid-session must be able to deal a session here
+		assigned, err := isDealerAssignedToCasino(rc.DB, dealerID, gameSummary.CasinoID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to check casino assignment"})
+			return
+		}
+		if !assigned {
+			ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Dealer is not assigned to this casino"})
+			return
+		}
+
+		clockedIn, err := isDealerClockedIn(rc.DB, dealerID, gameSummary.CasinoID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to check dealer shift"})
+			return
+		}
+		if !clockedIn {
+			ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Dealer is not clocked in at this casino"})
+			return
+		}
+	}
+
+	now := time.Now()
+	startTime := payload.StartTime
+	if startTime.IsZero() {
+		startTime = now
+	}
+	if payload.EndTime != nil && payload.EndTime.Before(startTime) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "End time cannot be before start time"})
+		return
+	}
+
+	newRound := models.Round{
+		GameSummaryID: gameSummary.ID,
+		RoundNumber:   payload.RoundNumber,
+		DealerID:      dealerID,
+		StartTime:     startTime,
+		EndTime:       payload.EndTime,
+		Outcome:       payload.Outcome,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	err := rc.DB.Transaction(func(tx *gorm.DB) error {
+		if newRound.RoundNumber == 0 {
+			var lastRoundNumber int
+			if err := tx.Model(&models.Round{}).Where("game_summary_id = ?", gameSummary.ID).Select("COALESCE(MAX(round_number), 0)").Scan(&lastRoundNumber).Error; err != nil {
+				return err
+			}
+			newRound.RoundNumber = lastRoundNumber + 1
+		}
+		if err := tx.Create(&newRound).Error; err != nil {
+			return err
+		}
+		if err := recordAudit(tx, ctx, models.AuditActionCreate, "round", newRound.ID, nil, newRound); err != nil {
+			return err
+		}
+		return recalculateGameSummaryTotals(tx, gameSummary.ID)
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "A round with that number already exists in this game summary"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to create round"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"status": "success", "data": convertToRoundResponse(newRound)})
+}
+
+// FindRounds godoc
+// @Summary List rounds of a game summary
+// @Description Retrieve every round of a game summary in order, including the transactions settled in each round
+// @Tags rounds
+// @Produce json
+// @Param gameSummaryId path string true "Game Summary ID"
+// @Success 200 {array} models.RoundResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /game-summaries/{gameSummaryId}/rounds [get]
+func (rc *RoundController) FindRounds(ctx *gin.Context) {
+	gameSummary, ok := rc.findGameSummary(ctx)
+	if !ok {
+		return
+	}
+
+	var rounds []models.Round
+	if err := rc.DB.Preload("Transactions.Player", withDeleted).Where("game_summary_id = ?", gameSummary.ID).Order("round_number ASC").Find(&rounds).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch rounds"})
+		return
+	}
+
+	responses := make([]models.RoundResponse, len(rounds))
+	for i, round := range rounds {
+		responses[i] = convertToRoundResponse(round)
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "results": len(responses), "data": responses})
+}
+
+// UpdateRound godoc
+// @Summary Complete a round
+// @Description Record the end time and outcome of a round
+// @Tags rounds
+// @Accept json
+// @Produce json
+// @Param gameSummaryId path string true "Game Summary ID"
+// @Param roundId path string true "Round ID"
+// @Param payload body models.UpdateRoundRequest true "Update round payload"
+// @Success 200 {object} models.RoundResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /game-summaries/{gameSummaryId}/rounds/{roundId} [put]
+func (rc *RoundController) UpdateRound(ctx *gin.Context) {
+	gameSummary, ok := rc.findGameSummary(ctx)
+	if !ok {
+		return
+	}
+
+	roundId, err := uuid.Parse(ctx.Param("roundId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid round ID"})
+		return
+	}
+
+	var payload models.UpdateRoundRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	if gameSummary.IsClosed() {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Cannot change rounds of a " + gameSummary.Status + " game summary"})
+		return
+	}
+
+	if len(payload.Outcome) > 0 && !json.Valid(payload.Outcome) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Outcome must be valid JSON"})
+		return
+	}
+
+	var round models.Round
+	if err := rc.DB.First(&round, "id = ? AND game_summary_id = ?", roundId, gameSummary.ID).Error; err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No round with that ID exists"})
+		return
+	}
+
+	if payload.EndTime != nil && payload.EndTime.Before(round.StartTime) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "End time cannot be before start time"})
+		return
+	}
+
+	updates := map[string]interface{}{"updated_at": time.Now()}
+	if payload.EndTime != nil {
+		updates["end_time"] = payload.EndTime
+	}
+	if len(payload.Outcome) > 0 {
+		updates["outcome"] = payload.Outcome
+	}
+
+	before := round
+	if err := rc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&round).Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := tx.First(&round, "id = ?", round.ID).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, ctx, models.AuditActionUpdate, "round", round.ID, before, round)
+	}); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to update round"})
+		return
+	}
+
+	if err := rc.DB.Preload("Transactions.Player", withDeleted).First(&round, "id = ?", round.ID).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch updated round"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": convertToRoundResponse(round)})
+}
+
+func (rc *RoundController) findGameSummary(ctx *gin.Context) (models.GameSummary, bool) {
+	gameSummaryId, err := uuid.Parse(ctx.Param("gameSummaryId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid game summary ID"})
+		return models.GameSummary{}, false
+	}
+
+	var gameSummary models.GameSummary
+	if err := scopeToCasinos(ctx, rc.DB, "casino_id").First(&gameSummary, "id = ?", gameSummaryId).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No game summary with that ID exists"})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch game summary"})
+		}
+		return models.GameSummary{}, false
+	}
+
+	return gameSummary, true
+}
+
+func convertToRoundResponse(round models.Round) models.RoundResponse {
+	return models.RoundResponse{
+		ID:            round.ID,
+		GameSummaryID: round.GameSummaryID,
+		RoundNumber:   round.RoundNumber,
+		DealerID:      round.DealerID,
+		StartTime:     round.StartTime,
+		EndTime:       round.EndTime,
+		Outcome:       round.Outcome,
+		Transactions:  convertToTransactionResponses(round.Transactions),
+		CreatedAt:     round.CreatedAt,
+		UpdatedAt:     round.UpdatedAt,
+	}
+}
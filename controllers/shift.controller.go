@@ -0,0 +1,584 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/suidevv/tableye-api/models"
+	"gorm.io/gorm"
+)
+
+// clockInGrace is how early a dealer may clock in to a planned shift.
+const clockInGrace = time.Hour
+
+type ShiftController struct {
+	DB *gorm.DB
+}
+
+func NewShiftController(DB *gorm.DB) ShiftController {
+	return ShiftController{DB}
+}
+
+// CreateShift godoc
+//
+//	@Summary		Plan a shift
+//	@Description	Plan a shift for a dealer at one of the casinos they are assigned to
+//	@Tags			shifts
+//	@Accept			json
+//	@Produce		json
+//	@Param			shift	body		models.CreateShiftRequest	true	"Create shift request"
+//	@Success		201		{object}	models.ShiftResponse
+//	@Failure		400		{object}	map[string]interface{}
+//	@Failure		403		{object}	map[string]interface{}
+//	@Failure		409		{object}	map[string]interface{}
+//	@Failure		500		{object}	map[string]interface{}
+//	@Router			/shifts [post]
+func (sc *ShiftController) CreateShift(ctx *gin.Context) {
+	var payload *models.CreateShiftRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	dealerID, err := uuid.Parse(payload.DealerID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid dealer ID"})
+		return
+	}
+
+	casinoID, err := uuid.Parse(payload.CasinoID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid casino ID"})
+		return
+	}
+
+	if !payload.PlannedEnd.After(payload.PlannedStart) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Planned end must be after planned start"})
+		return
+	}
+
+	if !requireCasinoAccess(ctx, casinoID) {
+		return
+	}
+
+	assigned, err := isDealerAssignedToCasino(sc.DB, dealerID, casinoID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to check casino assignment"})
+		return
+	}
+	if !assigned {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Dealer is not assigned to this casino"})
+		return
+	}
+
+	var overlapping int64
+	if err := sc.DB.Model(&models.Shift{}).
+		Where("dealer_id = ? AND status <> ? AND planned_start < ? AND planned_end > ?",
+			dealerID, models.ShiftStatusCompleted, payload.PlannedEnd, payload.PlannedStart).
+		Count(&overlapping).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to check overlapping shifts"})
+		return
+	}
+	if overlapping > 0 {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Dealer already has a shift planned in that period"})
+		return
+	}
+
+	now := time.Now()
+	newShift := models.Shift{
+		DealerID:     dealerID,
+		CasinoID:     casinoID,
+		PlannedStart: &payload.PlannedStart,
+		PlannedEnd:   &payload.PlannedEnd,
+		Status:       models.ShiftStatusPlanned,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	err = sc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&newShift).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, ctx, models.AuditActionCreate, "shift", newShift.ID, nil, newShift)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to create shift"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"status": "success", "data": convertToShiftResponse(newShift)})
+}
+
+// FindShifts godoc
+//
+//	@Summary		List shifts
+//	@Description	Get a list of planned and worked shifts, newest first
+//	@Tags			shifts
+//	@Produce		json
+//	@Param			page		query		int		false	"Page number"				default(1)
+//	@Param			limit		query		int		false	"Number of items per page"	default(10)
+//	@Param			casino_id	query		string	false	"Casino ID to filter by"
+//	@Param			dealer_id	query		string	false	"Dealer ID to filter by"
+//	@Param			status		query		string	false	"Status to filter by (planned, active or completed)"
+//	@Param			from		query		string	false	"Shifts starting from this date (YYYY-MM-DD or RFC 3339)"
+//	@Param			to			query		string	false	"Shifts starting before the end of this date (YYYY-MM-DD or RFC 3339)"
+//	@Success		200			{object}	map[string]interface{}
+//	@Failure		400			{object}	map[string]interface{}
+//	@Failure		500			{object}	map[string]interface{}
+//	@Router			/shifts [get]
+func (sc *ShiftController) FindShifts(ctx *gin.Context) {
+	var page = ctx.DefaultQuery("page", "1")
+	var limit = ctx.DefaultQuery("limit", "10")
+
+	intPage, _ := strconv.Atoi(page)
+	intLimit, _ := strconv.Atoi(limit)
+	offset := (intPage - 1) * intLimit
+
+	from, to, err := parseTimeRange(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	query := scopeToCasinos(ctx, sc.DB.Model(&models.Shift{}).Preload("Breaks"), "casino_id")
+
+	if casinoID := ctx.Query("casino_id"); casinoID != "" {
+		if _, err := uuid.Parse(casinoID); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid casino ID"})
+			return
+		}
+		query = query.Where("casino_id = ?", casinoID)
+	}
+	if dealerID := ctx.Query("dealer_id"); dealerID != "" {
+		if _, err := uuid.Parse(dealerID); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid dealer ID"})
+			return
+		}
+		query = query.Where("dealer_id = ?", dealerID)
+	}
+	if status := ctx.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	// Unplanned shifts only have a clock-in time
+	if from != nil {
+		query = query.Where("COALESCE(planned_start, clock_in_at) >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("COALESCE(planned_start, clock_in_at) < ?", *to)
+	}
+
+	var shifts []models.Shift
+	if err := query.Order("COALESCE(planned_start, clock_in_at) DESC").Limit(intLimit).Offset(offset).Find(&shifts).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch shifts"})
+		return
+	}
+
+	responses := make([]models.ShiftResponse, len(shifts))
+	for i, shift := range shifts {
+		responses[i] = convertToShiftResponse(shift)
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "results": len(responses), "data": responses})
+}
+
+// DeleteShift godoc
+//
+//	@Summary		Cancel a planned shift
+//	@Description	Delete a shift that has not been clocked in to yet
+//	@Tags			shifts
+//	@Produce		json
+//	@Param			shiftId	path	string	true	"Shift ID"
+//	@Success		204		"No Content"
+//	@Failure		404		{object}	map[string]interface{}
+//	@Failure		409		{object}	map[string]interface{}
+//	@Router			/shifts/{shiftId} [delete]
+func (sc *ShiftController) DeleteShift(ctx *gin.Context) {
+	shiftId := ctx.Param("shiftId")
+
+	var shift models.Shift
+	if err := scopeToCasinos(ctx, sc.DB, "casino_id").First(&shift, "id = ?", shiftId).Error; err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No shift with that ID exists"})
+		return
+	}
+
+	if shift.Status != models.ShiftStatusPlanned {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Only planned shifts can be cancelled"})
+		return
+	}
+
+	err := sc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&shift).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, ctx, models.AuditActionDelete, "shift", shift.ID, shift, nil)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to cancel shift"})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// FindCurrentShift godoc
+//
+//	@Summary		Get my current shift
+//	@Description	Get the shift the logged-in dealer is clocked in to
+//	@Tags			shifts
+//	@Produce		json
+//	@Success		200	{object}	models.ShiftResponse
+//	@Failure		403	{object}	map[string]interface{}
+//	@Failure		404	{object}	map[string]interface{}
+//	@Router			/shifts/current [get]
+func (sc *ShiftController) FindCurrentShift(ctx *gin.Context) {
+	dealer, ok := sc.currentDealer(ctx)
+	if !ok {
+		return
+	}
+
+	shift, err := findActiveShift(sc.DB, dealer.ID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "You are not clocked in"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": convertToShiftResponse(shift)})
+}
+
+// ClockIn godoc
+//
+//	@Summary		Clock in
+//	@Description	Clock the logged-in dealer in at a casino, starting their planned shift there if one is due
+//	@Tags			shifts
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		models.ClockInRequest	true	"Clock in request"
+//	@Success		200		{object}	models.ShiftResponse
+//	@Failure		400		{object}	map[string]interface{}
+//	@Failure		403		{object}	map[string]interface{}
+//	@Failure		409		{object}	map[string]interface{}
+//	@Router			/shifts/clock-in [post]
+func (sc *ShiftController) ClockIn(ctx *gin.Context) {
+	var payload *models.ClockInRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	casinoID, err := uuid.Parse(payload.CasinoID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid casino ID"})
+		return
+	}
+
+	dealer, ok := sc.currentDealer(ctx)
+	if !ok {
+		return
+	}
+
+	assigned, err := isDealerAssignedToCasino(sc.DB, dealer.ID, casinoID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to check casino assignment"})
+		return
+	}
+	if !assigned {
+		ctx.JSON(http.StatusForbidden, gin.H{"status": "fail", "message": "You are not assigned to this casino"})
+		return
+	}
+
+	if _, err := findActiveShift(sc.DB, dealer.ID); err == nil {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "You are already clocked in"})
+		return
+	}
+
+	now := time.Now()
+	var shift models.Shift
+	err = sc.DB.Transaction(func(tx *gorm.DB) error {
+		planned := tx.Where("dealer_id = ? AND casino_id = ? AND status = ? AND planned_start <= ? AND planned_end > ?",
+			dealer.ID, casinoID, models.ShiftStatusPlanned, now.Add(clockInGrace), now).
+			Order("planned_start").
+			First(&shift)
+
+		if planned.Error == nil {
+			before := shift
+			if err := tx.Model(&shift).Updates(map[string]interface{}{
+				"clock_in_at": now,
+				"status":      models.ShiftStatusActive,
+				"updated_at":  now,
+			}).Error; err != nil {
+				return err
+			}
+			if err := auditShiftChange(tx, ctx, before); err != nil {
+				return err
+			}
+		} else if errors.Is(planned.Error, gorm.ErrRecordNotFound) {
+			shift = models.Shift{
+				DealerID:  dealer.ID,
+				CasinoID:  casinoID,
+				ClockInAt: &now,
+				Status:    models.ShiftStatusActive,
+				CreatedAt: now,
+				UpdatedAt: now,
+			}
+			if err := tx.Create(&shift).Error; err != nil {
+				return err
+			}
+			if err := recordAudit(tx, ctx, models.AuditActionCreate, "shift", shift.ID, nil, shift); err != nil {
+				return err
+			}
+		} else {
+			return planned.Error
+		}
+
+		return tx.Model(&dealer).Updates(map[string]interface{}{"last_active_at": now, "updated_at": now}).Error
+	})
+	if err != nil {
+		// A concurrent clock-in won the race for the one active shift
+		if strings.Contains(err.Error(), "duplicate key") {
+			ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "You are already clocked in"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to clock in"})
+		return
+	}
+
+	sc.respondWithShift(ctx, shift.ID)
+}
+
+// ClockOut godoc
+//
+//	@Summary		Clock out
+//	@Description	Clock the logged-in dealer out, ending any break in progress
+//	@Tags			shifts
+//	@Produce		json
+//	@Success		200	{object}	models.ShiftResponse
+//	@Failure		403	{object}	map[string]interface{}
+//	@Failure		409	{object}	map[string]interface{}
+//	@Router			/shifts/clock-out [post]
+func (sc *ShiftController) ClockOut(ctx *gin.Context) {
+	dealer, ok := sc.currentDealer(ctx)
+	if !ok {
+		return
+	}
+
+	shift, err := findActiveShift(sc.DB, dealer.ID)
+	if err != nil {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "You are not clocked in"})
+		return
+	}
+
+	now := time.Now()
+	err = sc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ShiftBreak{}).Where("shift_id = ? AND end_time IS NULL", shift.ID).
+			Updates(map[string]interface{}{"end_time": now, "updated_at": now}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&shift).Updates(map[string]interface{}{
+			"clock_out_at": now,
+			"status":       models.ShiftStatusCompleted,
+			"updated_at":   now,
+		}).Error; err != nil {
+			return err
+		}
+		if err := auditShiftChange(tx, ctx, shift); err != nil {
+			return err
+		}
+		return tx.Model(&dealer).Updates(map[string]interface{}{"last_active_at": now, "updated_at": now}).Error
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to clock out"})
+		return
+	}
+
+	sc.respondWithShift(ctx, shift.ID)
+}
+
+// StartBreak godoc
+//
+//	@Summary		Start a break
+//	@Description	Start a break in the logged-in dealer's current shift
+//	@Tags			shifts
+//	@Produce		json
+//	@Success		200	{object}	models.ShiftResponse
+//	@Failure		403	{object}	map[string]interface{}
+//	@Failure		409	{object}	map[string]interface{}
+//	@Router			/shifts/breaks/start [post]
+func (sc *ShiftController) StartBreak(ctx *gin.Context) {
+	dealer, ok := sc.currentDealer(ctx)
+	if !ok {
+		return
+	}
+
+	shift, err := findActiveShift(sc.DB, dealer.ID)
+	if err != nil {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "You are not clocked in"})
+		return
+	}
+
+	if shiftOnBreak(shift) {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "You are already on a break"})
+		return
+	}
+
+	now := time.Now()
+	newBreak := models.ShiftBreak{
+		ShiftID:   shift.ID,
+		StartTime: now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	err = sc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&newBreak).Error; err != nil {
+			return err
+		}
+		return auditShiftChange(tx, ctx, shift)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to start break"})
+		return
+	}
+
+	sc.respondWithShift(ctx, shift.ID)
+}
+
+// EndBreak godoc
+//
+//	@Summary		End a break
+//	@Description	End the break in progress in the logged-in dealer's current shift
+//	@Tags			shifts
+//	@Produce		json
+//	@Success		200	{object}	models.ShiftResponse
+//	@Failure		403	{object}	map[string]interface{}
+//	@Failure		409	{object}	map[string]interface{}
+//	@Router			/shifts/breaks/end [post]
+func (sc *ShiftController) EndBreak(ctx *gin.Context) {
+	dealer, ok := sc.currentDealer(ctx)
+	if !ok {
+		return
+	}
+
+	shift, err := findActiveShift(sc.DB, dealer.ID)
+	if err != nil {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "You are not clocked in"})
+		return
+	}
+
+	now := time.Now()
+	errNotOnBreak := errors.New("not on a break")
+	err = sc.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.ShiftBreak{}).Where("shift_id = ? AND end_time IS NULL", shift.ID).
+			Updates(map[string]interface{}{"end_time": now, "updated_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errNotOnBreak
+		}
+		return auditShiftChange(tx, ctx, shift)
+	})
+	if err != nil {
+		if errors.Is(err, errNotOnBreak) {
+			ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "You are not on a break"})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to end break"})
+		}
+		return
+	}
+
+	sc.respondWithShift(ctx, shift.ID)
+}
+
+// currentDealer returns the dealer profile of the logged-in user.
+func (sc *ShiftController) currentDealer(ctx *gin.Context) (models.Dealer, bool) {
+	currentUser := ctx.MustGet("currentUser").(models.User)
+
+	var dealer models.Dealer
+	if err := sc.DB.First(&dealer, "user_id = ?", currentUser.ID).Error; err != nil {
+		ctx.JSON(http.StatusForbidden, gin.H{"status": "fail", "message": "You do not have a dealer profile"})
+		return models.Dealer{}, false
+	}
+
+	return dealer, true
+}
+
+func (sc *ShiftController) respondWithShift(ctx *gin.Context, shiftID uuid.UUID) {
+	var shift models.Shift
+	if err := sc.DB.Preload("Breaks").First(&shift, "id = ?", shiftID).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch shift"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": convertToShiftResponse(shift)})
+}
+
+// auditShiftChange records the change from before to the shift as it now is,
+// breaks included, so break starts and ends show up on the shift.
+func auditShiftChange(tx *gorm.DB, ctx *gin.Context, before models.Shift) error {
+	var after models.Shift
+	if err := tx.Preload("Breaks").First(&after, "id = ?", before.ID).Error; err != nil {
+		return err
+	}
+	return recordAudit(tx, ctx, models.AuditActionUpdate, "shift", after.ID, before, after)
+}
+
+// findActiveShift returns the shift the dealer is clocked in to, with its breaks.
+func findActiveShift(db *gorm.DB, dealerID uuid.UUID) (models.Shift, error) {
+	var shift models.Shift
+	err := db.Preload("Breaks").Where("dealer_id = ? AND status = ?", dealerID, models.ShiftStatusActive).First(&shift).Error
+	return shift, err
+}
+
+// isDealerClockedIn reports whether the dealer is on an active shift at the casino.
+func isDealerClockedIn(db *gorm.DB, dealerID uuid.UUID, casinoID uuid.UUID) (bool, error) {
+	var count int64
+	err := db.Model(&models.Shift{}).
+		Where("dealer_id = ? AND casino_id = ? AND status = ?", dealerID, casinoID, models.ShiftStatusActive).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func isDealerAssignedToCasino(db *gorm.DB, dealerID uuid.UUID, casinoID uuid.UUID) (bool, error) {
+	var count int64
+	err := db.Table("casino_dealers").Where("dealer_id = ? AND casino_id = ?", dealerID, casinoID).Count(&count).Error
+	return count > 0, err
+}
+
+func shiftOnBreak(shift models.Shift) bool {
+	for _, b := range shift.Breaks {
+		if b.EndTime == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func convertToShiftResponse(shift models.Shift) models.ShiftResponse {
+	breaks := make([]models.ShiftBreakResponse, len(shift.Breaks))
+	for i, b := range shift.Breaks {
+		breaks[i] = models.ShiftBreakResponse{
+			ID:        b.ID,
+			StartTime: b.StartTime,
+			EndTime:   b.EndTime,
+		}
+	}
+
+	return models.ShiftResponse{
+		ID:           shift.ID,
+		DealerID:     shift.DealerID,
+		CasinoID:     shift.CasinoID,
+		PlannedStart: shift.PlannedStart,
+		PlannedEnd:   shift.PlannedEnd,
+		ClockInAt:    shift.ClockInAt,
+		ClockOutAt:   shift.ClockOutAt,
+		Status:       shift.Status,
+		OnBreak:      shiftOnBreak(shift),
+		Breaks:       breaks,
+		CreatedAt:    shift.CreatedAt,
+		UpdatedAt:    shift.UpdatedAt,
+	}
+}
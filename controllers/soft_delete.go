@@ -0,0 +1,16 @@
+package controllers
+
+import (
+	"gorm.io/gorm"
+)
+
+// withDeleted is a preload condition that also loads soft-deleted rows, so
+// bookkeeping that refers to a deleted player still says who it was.
+func withDeleted(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+// onlyDeleted restricts a query on a soft-deleted model to its deleted rows.
+func onlyDeleted(query *gorm.DB, table string) *gorm.DB {
+	return query.Unscoped().Where(table + ".deleted_at IS NOT NULL")
+}
@@ -0,0 +1,402 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/suidevv/tableye-api/models"
+	"gorm.io/gorm"
+)
+
+type TableController struct {
+	DB *gorm.DB
+}
+
+func NewTableController(DB *gorm.DB) TableController {
+	return TableController{DB}
+}
+
+// CreateTable godoc
+//
+//	@Summary		Add a table to a casino
+//	@Description	Add a physical table to a casino floor. The status defaults to open.
+//	@Tags			tables
+//	@Accept			json
+//	@Produce		json
+//	@Param			casinoId	path		string						true	"Casino ID"
+//	@Param			table		body		models.CreateTableRequest	true	"Create table request"
+//	@Success		201			{object}	models.TableResponse
+//	@Failure		400			{object}	map[string]interface{}
+//	@Failure		404			{object}	map[string]interface{}
+//	@Failure		409			{object}	map[string]interface{}
+//	@Failure		500			{object}	map[string]interface{}
+//	@Router			/casinos/{casinoId}/tables [post]
+func (tc *TableController) CreateTable(ctx *gin.Context) {
+	casino, ok := tc.findCasino(ctx)
+	if !ok {
+		return
+	}
+
+	var payload *models.CreateTableRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	status := payload.Status
+	if status == "" {
+		status = models.TableStatusOpen
+	}
+	if !models.IsValidTableStatus(status) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Status must be one of open, closed or maintenance"})
+		return
+	}
+
+	game, err := tc.findGame(payload.GameID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	if err := validateTableBetLimits(payload.MinBet, payload.MaxBet); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	now := time.Now()
+	newTable := models.Table{
+		CasinoID:    casino.ID,
+		TableNumber: payload.TableNumber,
+		GameID:      game.ID,
+		MinBet:      payload.MinBet,
+		MaxBet:      payload.MaxBet,
+		Status:      status,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	err = tc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&newTable).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, ctx, models.AuditActionCreate, "table", newTable.ID, nil, newTable)
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "A table with that number already exists in this casino"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to create table"})
+		return
+	}
+
+	newTable.Game = game
+	newTable.Casino = casino
+	ctx.JSON(http.StatusCreated, gin.H{"status": "success", "data": convertToTableResponse(newTable)})
+}
+
+// FindTables godoc
+//
+//	@Summary		List the tables of a casino
+//	@Description	Get the tables of a casino ordered by table number
+//	@Tags			tables
+//	@Produce		json
+//	@Param			casinoId	path		string	true	"Casino ID"
+//	@Param			page		query		int		false	"Page number"				default(1)
+//	@Param			limit		query		int		false	"Number of items per page"	default(10)
+//	@Param			status		query		string	false	"Status to filter by (open, closed or maintenance)"
+//	@Param			game_id		query		string	false	"Game ID to filter by"
+//	@Success		200			{object}	map[string]interface{}
+//	@Failure		400			{object}	map[string]interface{}
+//	@Failure		404			{object}	map[string]interface{}
+//	@Failure		500			{object}	map[string]interface{}
+//	@Router			/casinos/{casinoId}/tables [get]
+func (tc *TableController) FindTables(ctx *gin.Context) {
+	casino, ok := tc.findCasino(ctx)
+	if !ok {
+		return
+	}
+
+	var page = ctx.DefaultQuery("page", "1")
+	var limit = ctx.DefaultQuery("limit", "10")
+
+	intPage, _ := strconv.Atoi(page)
+	intLimit, _ := strconv.Atoi(limit)
+	offset := (intPage - 1) * intLimit
+
+	query := tc.DB.Preload("Game").Preload("Casino").Where("casino_id = ?", casino.ID)
+	if status := ctx.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if gameID := ctx.Query("game_id"); gameID != "" {
+		if _, err := uuid.Parse(gameID); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid game ID"})
+			return
+		}
+		query = query.Where("game_id = ?", gameID)
+	}
+
+	var tables []models.Table
+	if err := query.Order("table_number").Limit(intLimit).Offset(offset).Find(&tables).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch tables"})
+		return
+	}
+
+	responses := make([]models.TableResponse, len(tables))
+	for i, table := range tables {
+		responses[i] = convertToTableResponse(table)
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "results": len(responses), "data": responses})
+}
+
+// FindTableById godoc
+//
+//	@Summary		Get a table by ID
+//	@Description	Get a single table of a casino by its ID
+//	@Tags			tables
+//	@Produce		json
+//	@Param			casinoId	path		string	true	"Casino ID"
+//	@Param			tableId		path		string	true	"Table ID"
+//	@Success		200			{object}	models.TableResponse
+//	@Failure		404			{object}	map[string]interface{}
+//	@Router			/casinos/{casinoId}/tables/{tableId} [get]
+func (tc *TableController) FindTableById(ctx *gin.Context) {
+	table, ok := tc.findTable(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": convertToTableResponse(table)})
+}
+
+// UpdateTable godoc
+//
+//	@Summary		Update a table
+//	@Description	Update a table's number, game, bet limit overrides or status. Set clear_min_bet or clear_max_bet to fall back to the game's limit.
+//	@Tags			tables
+//	@Accept			json
+//	@Produce		json
+//	@Param			casinoId	path		string						true	"Casino ID"
+//	@Param			tableId		path		string						true	"Table ID"
+//	@Param			table		body		models.UpdateTableRequest	true	"Update table request"
+//	@Success		200			{object}	models.TableResponse
+//	@Failure		400			{object}	map[string]interface{}
+//	@Failure		404			{object}	map[string]interface{}
+//	@Failure		409			{object}	map[string]interface{}
+//	@Failure		500			{object}	map[string]interface{}
+//	@Router			/casinos/{casinoId}/tables/{tableId} [put]
+func (tc *TableController) UpdateTable(ctx *gin.Context) {
+	table, ok := tc.findTable(ctx)
+	if !ok {
+		return
+	}
+
+	var payload *models.UpdateTableRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	if payload.Status != "" && !models.IsValidTableStatus(payload.Status) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Status must be one of open, closed or maintenance"})
+		return
+	}
+
+	tableToUpdate := models.Table{
+		TableNumber: payload.TableNumber,
+		MinBet:      payload.MinBet,
+		MaxBet:      payload.MaxBet,
+		Status:      payload.Status,
+		UpdatedAt:   time.Now(),
+	}
+
+	if payload.GameID != "" {
+		game, err := tc.findGame(payload.GameID)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+			return
+		}
+		tableToUpdate.GameID = game.ID
+	}
+
+	if (payload.ClearMinBet && payload.MinBet != nil) || (payload.ClearMaxBet && payload.MaxBet != nil) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "A bet limit cannot be set and cleared at once"})
+		return
+	}
+
+	// Updates skips nil fields, so cleared overrides are written separately
+	minBet, maxBet := table.MinBet, table.MaxBet
+	cleared := map[string]interface{}{}
+	if payload.MinBet != nil {
+		minBet = payload.MinBet
+	}
+	if payload.ClearMinBet {
+		minBet = nil
+		cleared["min_bet"] = nil
+	}
+	if payload.MaxBet != nil {
+		maxBet = payload.MaxBet
+	}
+	if payload.ClearMaxBet {
+		maxBet = nil
+		cleared["max_bet"] = nil
+	}
+	if err := validateTableBetLimits(minBet, maxBet); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	before := table
+	err := tc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&table).Updates(tableToUpdate).Error; err != nil {
+			return err
+		}
+		if len(cleared) > 0 {
+			if err := tx.Model(&table).Updates(cleared).Error; err != nil {
+				return err
+			}
+		}
+		return recordAudit(tx, ctx, models.AuditActionUpdate, "table", table.ID, before, table)
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "A table with that number already exists in this casino"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to update table"})
+		return
+	}
+
+	if err := tc.DB.Preload("Game").Preload("Casino").First(&table, "id = ?", table.ID).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch updated table"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": convertToTableResponse(table)})
+}
+
+// DeleteTable godoc
+//
+//	@Summary		Remove a table
+//	@Description	Remove a table from a casino. Tables that have hosted sessions are kept for reporting and should be closed instead.
+//	@Tags			tables
+//	@Produce		json
+//	@Param			casinoId	path	string	true	"Casino ID"
+//	@Param			tableId		path	string	true	"Table ID"
+//	@Success		204			"No Content"
+//	@Failure		404			{object}	map[string]interface{}
+//	@Failure		409			{object}	map[string]interface{}
+//	@Router			/casinos/{casinoId}/tables/{tableId} [delete]
+func (tc *TableController) DeleteTable(ctx *gin.Context) {
+	table, ok := tc.findTable(ctx)
+	if !ok {
+		return
+	}
+
+	var sessions int64
+	// Deleted sessions count too, they can still be restored
+	if err := tc.DB.Unscoped().Model(&models.GameSummary{}).Where("table_id = ?", table.ID).Count(&sessions).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to check table sessions"})
+		return
+	}
+	if sessions > 0 {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Table has recorded sessions, close it instead"})
+		return
+	}
+
+	err := tc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&table).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, ctx, models.AuditActionDelete, "table", table.ID, table, nil)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to delete table"})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+func (tc *TableController) findCasino(ctx *gin.Context) (models.Casino, bool) {
+	casinoId, err := uuid.Parse(ctx.Param("casinoId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid casino ID format"})
+		return models.Casino{}, false
+	}
+
+	var casino models.Casino
+	if err := scopeToCasinos(ctx, tc.DB, "id").First(&casino, "id = ?", casinoId).Error; err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No casino with that ID exists"})
+		return models.Casino{}, false
+	}
+
+	return casino, true
+}
+
+func (tc *TableController) findTable(ctx *gin.Context) (models.Table, bool) {
+	casino, ok := tc.findCasino(ctx)
+	if !ok {
+		return models.Table{}, false
+	}
+
+	tableId, err := uuid.Parse(ctx.Param("tableId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid table ID format"})
+		return models.Table{}, false
+	}
+
+	var table models.Table
+	if err := tc.DB.Preload("Game").First(&table, "id = ? AND casino_id = ?", tableId, casino.ID).Error; err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No table with that ID exists in this casino"})
+		return models.Table{}, false
+	}
+	table.Casino = casino
+
+	return table, true
+}
+
+func (tc *TableController) findGame(gameID string) (models.Game, error) {
+	parsedGameID, err := uuid.Parse(gameID)
+	if err != nil {
+		return models.Game{}, errors.New("invalid game ID")
+	}
+
+	var game models.Game
+	if err := tc.DB.First(&game, "id = ?", parsedGameID).Error; err != nil {
+		return models.Game{}, errors.New("no game with that ID exists")
+	}
+
+	return game, nil
+}
+
+func validateTableBetLimits(minBet, maxBet *models.Money) error {
+	if (minBet != nil && *minBet < 0) || (maxBet != nil && *maxBet < 0) {
+		return errors.New("bet limits cannot be negative")
+	}
+	if minBet != nil && maxBet != nil && *minBet > *maxBet {
+		return errors.New("minimum bet cannot be greater than maximum bet")
+	}
+	return nil
+}
+
+func convertToTableResponse(table models.Table) models.TableResponse {
+	return models.TableResponse{
+		ID:          table.ID,
+		CasinoID:    table.CasinoID,
+		TableNumber: table.TableNumber,
+		Game:        models.GameResponse{ID: table.Game.ID, Name: table.Game.Name, Type: table.Game.Type},
+		MinBet:      table.MinBet,
+		MaxBet:      table.MaxBet,
+		Currency:    table.Casino.Currency,
+		Status:      table.Status,
+		CreatedAt:   table.CreatedAt,
+		UpdatedAt:   table.UpdatedAt,
+	}
+}
@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseTimeRange reads the optional from and to query parameters. Both accept
+// RFC 3339 timestamps or plain dates; a plain to date includes the whole day.
+func parseTimeRange(ctx *gin.Context) (*time.Time, *time.Time, error) {
+	from, err := parseTimeParam(ctx.Query("from"), false)
+	if err != nil {
+		return nil, nil, errors.New("invalid from date, use YYYY-MM-DD or RFC 3339")
+	}
+
+	to, err := parseTimeParam(ctx.Query("to"), true)
+	if err != nil {
+		return nil, nil, errors.New("invalid to date, use YYYY-MM-DD or RFC 3339")
+	}
+
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, nil, errors.New("from must be before to")
+	}
+
+	return from, to, nil
+}
+
+func parseTimeParam(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
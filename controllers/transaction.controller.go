@@ -1,8 +1,12 @@
 package controllers
 
 import (
+	"fmt"
+	"log"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -29,6 +33,9 @@ func NewTransactionController(DB *gorm.DB) TransactionController {
 //	@Param			transaction	body		models.CreateTransactionRequest	true	"Create transaction request"
 //	@Success		201			{object}	models.TransactionResponse
 //	@Failure		400			{object}	map[string]interface{}
+//	@Failure		404			{object}	map[string]interface{}
+//	@Failure		409			{object}	map[string]interface{}
+//	@Failure		422			{object}	models.RuleViolation
 //	@Failure		500			{object}	map[string]interface{}
 //	@Router			/transactions [post]
 func (tc *TransactionController) CreateTransaction(ctx *gin.Context) {
@@ -50,44 +57,105 @@ func (tc *TransactionController) CreateTransaction(ctx *gin.Context) {
 		return
 	}
 
-	if payload.Outcome == "win" && payload.Amount < 0 {
-		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Win amount cannot be negative"})
+	if message := checkOutcome(payload.Amount, payload.Outcome); message != "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": message})
 		return
 	}
-	if payload.Outcome == "loss" && payload.Amount > 0 {
-		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Loss amount should be negative"})
+
+	var gameSummary models.GameSummary
+	if err := scopeToCasinos(ctx, tc.DB, "casino_id").First(&gameSummary, "id = ?", gameSummaryID).Error; err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No game summary with that ID exists"})
+		return
+	}
+	if gameSummary.IsClosed() {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Cannot add transactions to a " + gameSummary.Status + " game summary"})
+		return
+	}
+
+	var player models.Player
+	if err := tc.DB.First(&player, "id = ?", playerID).Error; err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No player with that ID exists"})
+		return
+	}
+
+	violation, err := checkBetRules(tc.DB, gameSummaryID, payload.Amount)
+	if err != nil {
+		if respondMissingRate(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to check bet limits"})
 		return
 	}
+	if violation != nil {
+		respondRuleViolation(ctx, violation)
+		return
+	}
+
+	var roundID *uuid.UUID
+	if payload.RoundID != "" {
+		parsedRoundID, err := uuid.Parse(payload.RoundID)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid round ID"})
+			return
+		}
+		var round models.Round
+		if err := tc.DB.First(&round, "id = ? AND game_summary_id = ?", parsedRoundID, gameSummaryID).Error; err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Round does not belong to this game summary"})
+			return
+		}
+		roundID = &round.ID
+	}
 
 	now := time.Now()
 	newTransaction := models.Transaction{
 		GameSummaryID: gameSummaryID,
 		PlayerID:      playerID,
+		RoundID:       roundID,
 		Amount:        payload.Amount,
+		Currency:      gameSummary.Currency,
 		Outcome:       payload.Outcome,
 		CreatedAt:     now,
 		UpdatedAt:     now,
 	}
 
-	result := tc.DB.Create(&newTransaction)
-	if result.Error != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": result.Error.Error()})
+	if err := tc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&newTransaction).Error; err != nil {
+			return err
+		}
+		if err := recordAudit(tx, ctx, models.AuditActionCreate, "transaction", newTransaction.ID, nil, newTransaction); err != nil {
+			return err
+		}
+		if err := adjustPlayerWinnings(tx, playerID, newTransaction.Amount, gameSummary); err != nil {
+			return err
+		}
+		return recalculateGameSummaryTotals(tx, gameSummaryID)
+	}); err != nil {
+		if respondMissingRate(ctx, err) {
+			return
+		}
+		log.Printf("Failed to create transaction in game summary %s: %v", gameSummaryID, err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to create transaction"})
 		return
 	}
 
 	// Fetch the full transaction with associated player
-	if err := tc.DB.Preload("Player").First(&newTransaction, newTransaction.ID).Error; err != nil {
+	if err := tc.DB.Preload("Player", withDeleted).First(&newTransaction, newTransaction.ID).Error; err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch created transaction"})
 		return
 	}
 
 	response := models.TransactionResponse{
-		ID:        newTransaction.ID,
-		Player:    models.PlayerResponse{ID: newTransaction.Player.ID, Nickname: newTransaction.Player.Nickname},
-		Amount:    newTransaction.Amount,
-		Outcome:   newTransaction.Outcome,
-		CreatedAt: newTransaction.CreatedAt,
-		UpdatedAt: newTransaction.UpdatedAt,
+		ID:         newTransaction.ID,
+		Player:     models.PlayerResponse{ID: newTransaction.Player.ID, Nickname: newTransaction.Player.Nickname},
+		RoundID:    newTransaction.RoundID,
+		Amount:     newTransaction.Amount,
+		Currency:   newTransaction.Currency,
+		Outcome:    newTransaction.Outcome,
+		ReversesID: newTransaction.ReversesID,
+		CorrectsID: newTransaction.CorrectsID,
+		Reason:     newTransaction.Reason,
+		CreatedAt:  newTransaction.CreatedAt,
+		UpdatedAt:  newTransaction.UpdatedAt,
 	}
 
 	ctx.JSON(http.StatusCreated, gin.H{"status": "success", "data": response})
@@ -118,7 +186,7 @@ func (tc *TransactionController) FindTransactions(ctx *gin.Context) {
 	intLimit, _ := strconv.Atoi(limit)
 	offset := (intPage - 1) * intLimit
 
-	query := tc.DB.Model(&models.Transaction{}).Preload("Player")
+	query := scopeToGameSummaries(ctx, tc.DB.Model(&models.Transaction{}).Preload("Player", withDeleted), "game_summary_id")
 
 	if gameSummaryID != "" {
 		if _, err := uuid.Parse(gameSummaryID); err != nil {
@@ -153,12 +221,17 @@ func (tc *TransactionController) FindTransactions(ctx *gin.Context) {
 	transactionResponses := make([]models.TransactionResponse, len(transactions))
 	for i, transaction := range transactions {
 		transactionResponses[i] = models.TransactionResponse{
-			ID:        transaction.ID,
-			Player:    models.PlayerResponse{ID: transaction.Player.ID, Nickname: transaction.Player.Nickname},
-			Amount:    transaction.Amount,
-			Outcome:   transaction.Outcome,
-			CreatedAt: transaction.CreatedAt,
-			UpdatedAt: transaction.UpdatedAt,
+			ID:         transaction.ID,
+			Player:     models.PlayerResponse{ID: transaction.Player.ID, Nickname: transaction.Player.Nickname},
+			RoundID:    transaction.RoundID,
+			Amount:     transaction.Amount,
+			Currency:   transaction.Currency,
+			Outcome:    transaction.Outcome,
+			ReversesID: transaction.ReversesID,
+			CorrectsID: transaction.CorrectsID,
+			Reason:     transaction.Reason,
+			CreatedAt:  transaction.CreatedAt,
+			UpdatedAt:  transaction.UpdatedAt,
 		}
 	}
 
@@ -187,71 +260,423 @@ func (tc *TransactionController) FindTransactionById(ctx *gin.Context) {
 	transactionId := ctx.Param("transactionId")
 
 	var transaction models.Transaction
-	result := tc.DB.Preload("Player").First(&transaction, "id = ?", transactionId)
+	result := scopeToGameSummaries(ctx, tc.DB.Preload("Player", withDeleted), "game_summary_id").First(&transaction, "id = ?", transactionId)
 	if result.Error != nil {
 		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No transaction with that ID exists"})
 		return
 	}
 
 	response := models.TransactionResponse{
-		ID:        transaction.ID,
-		Player:    models.PlayerResponse{ID: transaction.Player.ID, Nickname: transaction.Player.Nickname},
-		Amount:    transaction.Amount,
-		Outcome:   transaction.Outcome,
-		CreatedAt: transaction.CreatedAt,
-		UpdatedAt: transaction.UpdatedAt,
+		ID:         transaction.ID,
+		Player:     models.PlayerResponse{ID: transaction.Player.ID, Nickname: transaction.Player.Nickname},
+		RoundID:    transaction.RoundID,
+		Amount:     transaction.Amount,
+		Currency:   transaction.Currency,
+		Outcome:    transaction.Outcome,
+		ReversesID: transaction.ReversesID,
+		CorrectsID: transaction.CorrectsID,
+		Reason:     transaction.Reason,
+		CreatedAt:  transaction.CreatedAt,
+		UpdatedAt:  transaction.UpdatedAt,
+	}
+
+	chain, err := correctionChain(tc.DB, transaction)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch correction chain"})
+		return
+	}
+	if len(chain) > 1 {
+		response.CorrectionChain = convertToTransactionResponses(chain)
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": response})
 }
 
-func (tc *TransactionController) UpdateTransaction(ctx *gin.Context) {
+// CorrectTransaction godoc
+//
+//	@Summary		Correct a transaction
+//	@Description	Transactions cannot be edited. A correction books a reversal of the original and a new entry with the corrected figures, both carrying the reason. Reversals cannot be corrected and a transaction can only be corrected once; correct its replacement instead.
+//	@Tags			transactions
+//	@Accept			json
+//	@Produce		json
+//	@Param			transactionId	path		string								true	"Transaction ID"
+//	@Param			correction		body		models.CorrectTransactionRequest	true	"Corrected figures and reason"
+//	@Success		201				{object}	models.CorrectionResponse
+//	@Failure		400				{object}	map[string]interface{}
+//	@Failure		404				{object}	map[string]interface{}
+//	@Failure		409				{object}	map[string]interface{}
+//	@Failure		422				{object}	models.RuleViolation
+//	@Failure		500				{object}	map[string]interface{}
+//	@Router			/transactions/{transactionId}/corrections [post]
+func (tc *TransactionController) CorrectTransaction(ctx *gin.Context) {
 	transactionId := ctx.Param("transactionId")
 
-	var payload models.UpdateTransactionRequest
+	var payload models.CorrectTransactionRequest
 	if err := ctx.ShouldBindJSON(&payload); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
 		return
 	}
+	payload.Reason = strings.TrimSpace(payload.Reason)
+	if payload.Reason == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "A reason is required"})
+		return
+	}
+	if message := checkOutcome(payload.Amount, payload.Outcome); message != "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": message})
+		return
+	}
 
-	var transaction models.Transaction
-	result := tc.DB.First(&transaction, "id = ?", transactionId)
-	if result.Error != nil {
+	var original models.Transaction
+	if err := scopeToGameSummaries(ctx, tc.DB, "game_summary_id").First(&original, "id = ?", transactionId).Error; err != nil {
 		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No transaction with that ID exists"})
 		return
 	}
+	if original.ReversesID != nil {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Reversal entries cannot be corrected"})
+		return
+	}
+	reversed, err := isReversed(tc.DB, original.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to check for earlier corrections"})
+		return
+	}
+	if reversed {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Transaction has already been corrected, correct its replacement instead"})
+		return
+	}
 
-	updates := models.Transaction{
-		Amount:  payload.Amount,
-		Outcome: payload.Outcome,
+	var gameSummary models.GameSummary
+	if err := tc.DB.First(&gameSummary, "id = ?", original.GameSummaryID).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch game summary"})
+		return
 	}
 
-	tc.DB.Model(&transaction).Updates(updates)
+	violation, err := checkBetRules(tc.DB, original.GameSummaryID, payload.Amount)
+	if err != nil {
+		if respondMissingRate(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to check bet limits"})
+		return
+	}
+	if violation != nil {
+		respondRuleViolation(ctx, violation)
+		return
+	}
 
-	// Fetch the updated transaction with player data
-	tc.DB.Preload("Player").First(&transaction, "id = ?", transactionId)
+	now := time.Now()
+	reversal := models.Transaction{
+		GameSummaryID: original.GameSummaryID,
+		PlayerID:      original.PlayerID,
+		RoundID:       original.RoundID,
+		Amount:        -original.Amount,
+		Currency:      original.Currency,
+		Type:          models.TransactionTypeReversal,
+		Outcome:       "win",
+		ReversesID:    &original.ID,
+		Reason:        payload.Reason,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if original.Outcome == "win" {
+		reversal.Outcome = "loss"
+	}
+	corrected := models.Transaction{
+		GameSummaryID: original.GameSummaryID,
+		PlayerID:      original.PlayerID,
+		RoundID:       original.RoundID,
+		Amount:        payload.Amount,
+		Currency:      original.Currency,
+		Type:          models.TransactionTypeCorrection,
+		Outcome:       payload.Outcome,
+		CorrectsID:    &original.ID,
+		Reason:        payload.Reason,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
 
-	response := models.TransactionResponse{
-		ID:        transaction.ID,
-		Player:    models.PlayerResponse{ID: transaction.Player.ID, Nickname: transaction.Player.Nickname},
-		Amount:    transaction.Amount,
-		Outcome:   transaction.Outcome,
-		CreatedAt: transaction.CreatedAt,
-		UpdatedAt: transaction.UpdatedAt,
+	if err := tc.DB.Transaction(func(tx *gorm.DB) error {
+		for _, entry := range []*models.Transaction{&reversal, &corrected} {
+			if err := tx.Create(entry).Error; err != nil {
+				return err
+			}
+			if err := recordAudit(tx, ctx, models.AuditActionCreate, "transaction", entry.ID, nil, *entry); err != nil {
+				return err
+			}
+		}
+		// Each entry is converted on its own, as the ledger sums them
+		for _, entry := range []models.Transaction{reversal, corrected} {
+			if err := adjustPlayerWinnings(tx, original.PlayerID, entry.Amount, gameSummary); err != nil {
+				return err
+			}
+		}
+		if err := recalculateGameSummaryTotals(tx, original.GameSummaryID); err != nil {
+			return err
+		}
+		// A correction counts against the dealer's accuracy
+		return RefreshDealerPerformance(tx, gameSummary.DealerID)
+	}); err != nil {
+		if respondMissingRate(ctx, err) {
+			return
+		}
+		// The unique reversal index catches a concurrent correction
+		if strings.Contains(err.Error(), "duplicate key") {
+			ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Transaction has already been corrected, correct its replacement instead"})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to correct transaction"})
+		}
+		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": response})
+	var player models.Player
+	tc.DB.Unscoped().First(&player, "id = ?", original.PlayerID)
+	reversal.Player = player
+	corrected.Player = player
+	responses := convertToTransactionResponses([]models.Transaction{reversal, corrected})
+
+	ctx.JSON(http.StatusCreated, gin.H{"status": "success", "data": models.CorrectionResponse{Reversal: responses[0], Corrected: responses[1]}})
 }
 
 func (tc *TransactionController) DeleteTransaction(ctx *gin.Context) {
 	transactionId := ctx.Param("transactionId")
 
-	result := tc.DB.Delete(&models.Transaction{}, "id = ?", transactionId)
-
-	if result.RowsAffected == 0 {
+	var transaction models.Transaction
+	if err := scopeToGameSummaries(ctx, tc.DB, "game_summary_id").First(&transaction, "id = ?", transactionId).Error; err != nil {
 		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No transaction with that ID exists"})
 		return
 	}
 
+	// Deleting part of a correction would leave the rest of it unbalanced
+	reversed, err := isReversed(tc.DB, transaction.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to check for corrections"})
+		return
+	}
+	if reversed || transaction.ReversesID != nil || transaction.CorrectsID != nil {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Transactions that are part of a correction cannot be deleted"})
+		return
+	}
+
+	if err := tc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&transaction).Error; err != nil {
+			return err
+		}
+		if err := recordAudit(tx, ctx, models.AuditActionDelete, "transaction", transaction.ID, transaction, nil); err != nil {
+			return err
+		}
+		var gameSummary models.GameSummary
+		if err := tx.First(&gameSummary, "id = ?", transaction.GameSummaryID).Error; err != nil {
+			return err
+		}
+		if err := adjustPlayerWinnings(tx, transaction.PlayerID, -transaction.Amount, gameSummary); err != nil {
+			return err
+		}
+		return recalculateGameSummaryTotals(tx, transaction.GameSummaryID)
+	}); err != nil {
+		if respondMissingRate(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to delete transaction"})
+		return
+	}
+
 	ctx.JSON(http.StatusNoContent, nil)
 }
+
+// FindDeletedTransactions godoc
+//
+//	@Summary		List deleted transactions
+//	@Description	Get deleted transactions, most recently deleted first
+//	@Tags			admin
+//	@Produce		json
+//	@Param			page			query		int		false	"Page number"				default(1)
+//	@Param			limit			query		int		false	"Number of items per page"	default(10)
+//	@Param			game_summary_id	query		string	false	"Game Summary ID to filter by"
+//	@Param			player_id		query		string	false	"Player ID to filter by"
+//	@Success		200				{object}	map[string]interface{}
+//	@Failure		400				{object}	map[string]interface{}
+//	@Failure		500				{object}	map[string]interface{}
+//	@Router			/admin/deleted/transactions [get]
+func (tc *TransactionController) FindDeletedTransactions(ctx *gin.Context) {
+	intPage, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	intLimit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	offset := (intPage - 1) * intLimit
+
+	query := scopeToGameSummaries(ctx, onlyDeleted(tc.DB.Model(&models.Transaction{}), "transactions"), "game_summary_id")
+
+	if gameSummaryID := ctx.Query("game_summary_id"); gameSummaryID != "" {
+		if _, err := uuid.Parse(gameSummaryID); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid game summary ID"})
+			return
+		}
+		query = query.Where("game_summary_id = ?", gameSummaryID)
+	}
+
+	if playerID := ctx.Query("player_id"); playerID != "" {
+		if _, err := uuid.Parse(playerID); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid player ID"})
+			return
+		}
+		query = query.Where("player_id = ?", playerID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to count deleted transactions"})
+		return
+	}
+
+	var transactions []models.Transaction
+	if err := query.Preload("Player", withDeleted).Order("deleted_at DESC").Limit(intLimit).Offset(offset).Find(&transactions).Error; err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch deleted transactions"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"results": len(transactions),
+		"total":   total,
+		"page":    intPage,
+		"limit":   intLimit,
+		"data":    transactions,
+	})
+}
+
+// RestoreTransaction godoc
+//
+//	@Summary		Restore a deleted transaction
+//	@Description	Bring back a deleted transaction together with its effect on the player's winnings and the session totals. The game summary and player must not be deleted.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			transactionId	path		string	true	"Transaction ID"
+//	@Success		200				{object}	models.TransactionResponse
+//	@Failure		404				{object}	map[string]interface{}
+//	@Failure		409				{object}	map[string]interface{}
+//	@Failure		500				{object}	map[string]interface{}
+//	@Router			/admin/deleted/transactions/{transactionId}/restore [post]
+func (tc *TransactionController) RestoreTransaction(ctx *gin.Context) {
+	var transaction models.Transaction
+	if err := scopeToGameSummaries(ctx, onlyDeleted(tc.DB, "transactions"), "game_summary_id").First(&transaction, "id = ?", ctx.Param("transactionId")).Error; err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"status": "fail", "message": "No deleted transaction with that ID exists"})
+		return
+	}
+
+	var gameSummary models.GameSummary
+	if err := tc.DB.First(&gameSummary, "id = ?", transaction.GameSummaryID).Error; err != nil {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "The game summary of this transaction is deleted, restore it instead"})
+		return
+	}
+
+	var player models.Player
+	if err := tc.DB.First(&player, "id = ?", transaction.PlayerID).Error; err != nil {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "The player of this transaction is deleted, restore them first"})
+		return
+	}
+
+	before := transaction
+	if err := tc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&transaction).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		transaction.DeletedAt = gorm.DeletedAt{}
+		if err := recordAudit(tx, ctx, models.AuditActionRestore, "transaction", transaction.ID, before, transaction); err != nil {
+			return err
+		}
+		if err := adjustPlayerWinnings(tx, transaction.PlayerID, transaction.Amount, gameSummary); err != nil {
+			return err
+		}
+		return recalculateGameSummaryTotals(tx, transaction.GameSummaryID)
+	}); err != nil {
+		if respondMissingRate(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to restore transaction"})
+		return
+	}
+
+	transaction.Player = player
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": convertToTransactionResponses([]models.Transaction{transaction})[0]})
+}
+
+// checkOutcome returns why an amount does not fit its outcome, or an empty
+// string if it does.
+func checkOutcome(amount models.Money, outcome string) string {
+	if outcome == "win" && amount < 0 {
+		return "Win amount cannot be negative"
+	}
+	if outcome == "loss" && amount > 0 {
+		return "Loss amount should be negative"
+	}
+	return ""
+}
+
+// isReversed reports whether a reversal entry exists for the transaction.
+func isReversed(db *gorm.DB, transactionID uuid.UUID) (bool, error) {
+	var count int64
+	err := db.Model(&models.Transaction{}).Where("reverses_id = ?", transactionID).Count(&count).Error
+	return count > 0, err
+}
+
+// notReversed is a SQL condition on the transactions aliased as alias that
+// leaves out reversal entries and the transactions they reverse. Each pair
+// cancels out, so sums are unaffected, but it would inflate pots, settled
+// volumes and bet counts.
+func notReversed(alias string) string {
+	return fmt.Sprintf("%[1]s.reverses_id IS NULL AND NOT EXISTS (SELECT 1 FROM transactions reversal WHERE reversal.reverses_id = %[1]s.id)", alias)
+}
+
+// correctionChain returns the original transaction that the given one descends
+// from through corrections and reversals, followed by every entry booked against
+// it or its replacements, oldest first.
+func correctionChain(db *gorm.DB, transaction models.Transaction) ([]models.Transaction, error) {
+	root := transaction
+	for root.ReversesID != nil || root.CorrectsID != nil {
+		parentID := root.ReversesID
+		if parentID == nil {
+			parentID = root.CorrectsID
+		}
+		var parent models.Transaction
+		if err := db.Preload("Player", withDeleted).First(&parent, "id = ?", *parentID).Error; err != nil {
+			return nil, err
+		}
+		root = parent
+	}
+
+	chain := []models.Transaction{root}
+	ids := []uuid.UUID{root.ID}
+	for len(ids) > 0 {
+		var entries []models.Transaction
+		if err := db.Preload("Player", withDeleted).Where("reverses_id IN ? OR corrects_id IN ?", ids, ids).Find(&entries).Error; err != nil {
+			return nil, err
+		}
+		ids = ids[:0]
+		for _, entry := range entries {
+			chain = append(chain, entry)
+			ids = append(ids, entry.ID)
+		}
+	}
+
+	// A reversal and its corrected entry share a timestamp, the reversal goes first
+	sort.SliceStable(chain, func(i, j int) bool {
+		if chain[i].CreatedAt.Equal(chain[j].CreatedAt) {
+			return chain[i].ReversesID != nil && chain[j].ReversesID == nil
+		}
+		return chain[i].CreatedAt.Before(chain[j].CreatedAt)
+	})
+	return chain, nil
+}
+
+// adjustPlayerWinnings moves a player's running total by delta, deleted players
+// included. It must run in the same DB transaction as the transaction row change
+// it accounts for. Totals span casinos, so they are kept in the reporting
+// currency: delta is in the session's currency and converted at its start date.
+func adjustPlayerWinnings(tx *gorm.DB, playerID uuid.UUID, delta models.Money, gameSummary models.GameSummary) error {
+	delta, err := convertMoney(tx, delta, gameSummary.Currency, models.DefaultCurrency, gameSummary.StartTime)
+	if err != nil || delta == 0 {
+		return err
+	}
+	return tx.Unscoped().Model(&models.Player{}).Where("id = ?", playerID).Updates(map[string]interface{}{
+		"total_winnings": gorm.Expr("total_winnings + ?", delta),
+		"updated_at":     time.Now(),
+	}).Error
+}
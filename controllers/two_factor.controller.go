@@ -0,0 +1,400 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/suidevv/tableye-api/initializers"
+	"github.com/suidevv/tableye-api/models"
+	"github.com/suidevv/tableye-api/utils"
+	"gorm.io/gorm"
+)
+
+const recoveryCodeCount = 10
+
+var errInvalidSecondFactor = errors.New("invalid two-factor code")
+
+// SetupTwoFactor godoc
+// @Summary Start two-factor enrolment
+// @Description Generates a new TOTP secret for the current user. Show the provisioning URI as a QR code, then confirm with /auth/2fa/enable. Starting again replaces a secret that was not confirmed yet.
+// @Tags authentication
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.TwoFactorSetupResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 502 {object} map[string]interface{}
+// @Router /auth/2fa/setup [post]
+func (ac *AuthController) SetupTwoFactor(ctx *gin.Context) {
+	currentUser := ctx.MustGet("currentUser").(models.User)
+
+	if currentUser.TwoFactorEnabled {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Two-factor authentication is already enabled"})
+		return
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": err.Error()})
+		return
+	}
+
+	err = ac.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&currentUser).
+			Updates(map[string]interface{}{"totp_secret": secret, "totp_last_step": 0, "updated_at": time.Now()}).Error; err != nil {
+			return err
+		}
+		// The secret itself is secret, only the start of enrolment is recorded
+		return recordAudit(tx, ctx, models.AuditActionUpdate, "two_factor", currentUser.ID, nil,
+			gin.H{"enrolment_started": true, "replaced_unconfirmed_secret": currentUser.TOTPSecret != ""})
+	})
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Failed to start two-factor enrolment"})
+		return
+	}
+
+	config, _ := initializers.LoadConfig(".")
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": models.TwoFactorSetupResponse{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(config.TwoFactorIssuer(), currentUser.Email, secret),
+	}})
+}
+
+// EnableTwoFactor godoc
+// @Summary Confirm two-factor enrolment
+// @Description Turns on two-factor authentication once the user proves their authenticator works, and returns recovery codes. The codes are only shown this once.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param request body models.TwoFactorCodeInput true "Code from the authenticator app"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 502 {object} map[string]interface{}
+// @Router /auth/2fa/enable [post]
+func (ac *AuthController) EnableTwoFactor(ctx *gin.Context) {
+	currentUser := ctx.MustGet("currentUser").(models.User)
+
+	var payload models.TwoFactorCodeInput
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	if currentUser.TwoFactorEnabled {
+		ctx.JSON(http.StatusConflict, gin.H{"status": "fail", "message": "Two-factor authentication is already enabled"})
+		return
+	}
+	if currentUser.TOTPSecret == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Start enrolment with /auth/2fa/setup first"})
+		return
+	}
+
+	var codes []string
+	err := ac.DB.Transaction(func(tx *gorm.DB) error {
+		if err := useTOTPCode(tx, currentUser, payload.Code); err != nil {
+			return err
+		}
+		if err := updateUserAudited(tx, ctx, currentUser.ID,
+			map[string]interface{}{"two_factor_enabled": true, "updated_at": time.Now()}); err != nil {
+			return err
+		}
+
+		var err error
+		codes, err = replaceRecoveryCodes(tx, currentUser)
+		return err
+	})
+	if errors.Is(err, errInvalidSecondFactor) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid two-factor code"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Failed to enable two-factor authentication"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"recovery_codes": codes}})
+}
+
+// DisableTwoFactor godoc
+// @Summary Turn off two-factor authentication
+// @Description Removes the user's TOTP secret and recovery codes. Not allowed for roles that require two-factor authentication.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param request body models.TwoFactorCodeInput true "Authenticator or recovery code"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 502 {object} map[string]interface{}
+// @Router /auth/2fa/disable [post]
+func (ac *AuthController) DisableTwoFactor(ctx *gin.Context) {
+	currentUser := ctx.MustGet("currentUser").(models.User)
+
+	var payload models.TwoFactorCodeInput
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	if !currentUser.TwoFactorEnabled {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Two-factor authentication is not enabled"})
+		return
+	}
+
+	config, _ := initializers.LoadConfig(".")
+	if config.TwoFactorRequired(currentUser.Role) {
+		ctx.JSON(http.StatusForbidden, gin.H{"status": "fail", "message": "Two-factor authentication is required for your role"})
+		return
+	}
+
+	err := ac.DB.Transaction(func(tx *gorm.DB) error {
+		if err := useSecondFactor(tx, currentUser, payload.Code); err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", currentUser.ID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return updateUserAudited(tx, ctx, currentUser.ID, map[string]interface{}{
+			"two_factor_enabled": false,
+			"totp_secret":        "",
+			"totp_last_step":     0,
+			"updated_at":         time.Now(),
+		})
+	})
+	if errors.Is(err, errInvalidSecondFactor) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid two-factor code"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Failed to disable two-factor authentication"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "message": "Two-factor authentication disabled"})
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary Replace recovery codes
+// @Description Invalidates the user's recovery codes and returns a new set. The codes are only shown this once.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param request body models.TwoFactorCodeInput true "Code from the authenticator app"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 502 {object} map[string]interface{}
+// @Router /auth/2fa/recovery-codes [post]
+func (ac *AuthController) RegenerateRecoveryCodes(ctx *gin.Context) {
+	currentUser := ctx.MustGet("currentUser").(models.User)
+
+	var payload models.TwoFactorCodeInput
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	if !currentUser.TwoFactorEnabled {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Two-factor authentication is not enabled"})
+		return
+	}
+
+	var codes []string
+	err := ac.DB.Transaction(func(tx *gorm.DB) error {
+		if err := useTOTPCode(tx, currentUser, payload.Code); err != nil {
+			return err
+		}
+
+		var err error
+		if codes, err = replaceRecoveryCodes(tx, currentUser); err != nil {
+			return err
+		}
+		// The codes themselves are secret, only their replacement is recorded
+		return recordAudit(tx, ctx, models.AuditActionUpdate, "recovery_codes", currentUser.ID, nil, gin.H{"count": len(codes)})
+	})
+	if errors.Is(err, errInvalidSecondFactor) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid two-factor code"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Failed to replace recovery codes"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"recovery_codes": codes}})
+}
+
+// VerifyTwoFactor godoc
+// @Summary Complete a two-factor sign-in
+// @Description Exchanges the challenge token from /auth/login and an authenticator or recovery code for access and refresh tokens. Each challenge completes one sign-in. Wrong codes count as failed sign-ins.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param request body models.TwoFactorVerifyInput true "Challenge token and code"
+// @Success 200 {object} models.SignInResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Failure 502 {object} map[string]interface{}
+// @Router /auth/2fa/verify [post]
+func (ac *AuthController) VerifyTwoFactor(ctx *gin.Context) {
+	var payload models.TwoFactorVerifyInput
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": err.Error()})
+		return
+	}
+
+	config, _ := initializers.LoadConfig(".")
+	claims, err := ac.Tokens.ValidateToken(utils.TokenTypeTwoFactorChallenge, payload.ChallengeToken)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"status": "fail", "message": "Invalid or expired challenge, please log in again"})
+		return
+	}
+
+	var user models.User
+	if err := ac.DB.First(&user, "id = ?", claims.Subject).Error; err != nil || !user.TwoFactorEnabled {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"status": "fail", "message": "Invalid or expired challenge, please log in again"})
+		return
+	}
+
+	clientIP := ctx.ClientIP()
+	if wait := signInBlocked(config, user.Email, clientIP); wait > 0 {
+		respondSignInBlocked(ctx, wait)
+		return
+	}
+
+	err = ac.DB.Transaction(func(tx *gorm.DB) error {
+		// Spent together with the code, so a wrong code leaves the challenge usable
+		token, err := consumeUserToken(tx, claims.TokenID, models.TokenPurposeTwoFactorChallenge)
+		if err != nil {
+			return err
+		}
+		if token.UserID != user.ID {
+			return errInvalidUserToken
+		}
+		return useSecondFactor(tx, user, payload.Code)
+	})
+	if errors.Is(err, errInvalidUserToken) {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"status": "fail", "message": "Invalid or expired challenge, please log in again"})
+		return
+	}
+	if errors.Is(err, errInvalidSecondFactor) {
+		recordFailedSignIn(ac.DB, config, user.Email, clientIP, &user)
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "fail", "message": "Invalid two-factor code"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Failed to check two-factor code"})
+		return
+	}
+
+	if err := config.AccountLimiter().Reset(accountLimitKey(user.Email)); err != nil {
+		log.Printf("Failed to reset sign-in limit for %s: %v", user.Email, err)
+	}
+
+	ac.completeSignIn(ctx, config, user)
+}
+
+// sendTwoFactorChallenge answers a correct password of a user with 2FA with
+// a short-lived token for /auth/2fa/verify instead of a session. The token ID
+// is stored as a user token, so the challenge can be used once and a new
+// sign-in replaces the user's outstanding challenge.
+func (ac *AuthController) sendTwoFactorChallenge(ctx *gin.Context, config initializers.Config, user models.User) {
+	tokenID, err := issueUserToken(ac.DB, user.ID, models.TokenPurposeTwoFactorChallenge, config.TwoFactorChallengeTTL())
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": "Failed to create two-factor challenge"})
+		return
+	}
+
+	challenge, _, err := ac.Tokens.CreateToken(utils.TokenClaims{
+		Subject: user.ID.String(),
+		TokenID: tokenID,
+		Type:    utils.TokenTypeTwoFactorChallenge,
+	}, config.TwoFactorChallengeTTL())
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"status": "error", "message": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, models.TwoFactorChallengeResponse{
+		Status:            "success",
+		TwoFactorRequired: true,
+		ChallengeToken:    challenge,
+	})
+}
+
+// useSecondFactor accepts a TOTP code or, failing that, an unused recovery
+// code of user and spends it.
+func useSecondFactor(tx *gorm.DB, user models.User, code string) error {
+	err := useTOTPCode(tx, user, code)
+	if !errors.Is(err, errInvalidSecondFactor) {
+		return err
+	}
+
+	result := tx.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", user.ID, utils.HashSecret(utils.NormalizeRecoveryCode(code))).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errInvalidSecondFactor
+	}
+	return nil
+}
+
+// useTOTPCode checks code against user's secret. Each time step can be used
+// once, so a code seen over someone's shoulder cannot be replayed.
+func useTOTPCode(tx *gorm.DB, user models.User, code string) error {
+	if user.TOTPSecret == "" {
+		return errInvalidSecondFactor
+	}
+
+	step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now())
+	if !ok {
+		return errInvalidSecondFactor
+	}
+
+	result := tx.Model(&models.User{}).
+		Where("id = ? AND totp_last_step < ?", user.ID, step).
+		Update("totp_last_step", step)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errInvalidSecondFactor
+	}
+	return nil
+}
+
+// replaceRecoveryCodes deletes user's recovery codes and returns a new set.
+func replaceRecoveryCodes(tx *gorm.DB, user models.User) ([]string, error) {
+	codes, err := utils.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Where("user_id = ?", user.ID).Delete(&models.RecoveryCode{}).Error; err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	records := make([]models.RecoveryCode, len(codes))
+	for i, code := range codes {
+		records[i] = models.RecoveryCode{UserID: user.ID, CodeHash: utils.HashSecret(code), CreatedAt: now}
+	}
+	if err := tx.Create(&records).Error; err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
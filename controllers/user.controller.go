@@ -31,3 +31,25 @@ func (uc *UserController) GetMe(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"user": userResponse}})
 }
+
+// GetMyPermissions godoc
+// @Summary Get the current user's permissions
+// @Description Returns the permissions granted to the current user's role so clients can hide actions they cannot perform
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /users/me/permissions [get]
+func (uc *UserController) GetMyPermissions(ctx *gin.Context) {
+	currentUser := ctx.MustGet("currentUser").(models.User)
+
+	permissions, err := findRolePermissions(uc.DB, currentUser.Role)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to fetch permissions"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"role": currentUser.Role, "permissions": permissions}})
+}
@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/suidevv/tableye-api/initializers"
+	"github.com/suidevv/tableye-api/models"
+	"github.com/suidevv/tableye-api/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var errInvalidUserToken = errors.New("invalid or expired token")
+
+// issueUserToken replaces any outstanding token of purpose for the user and
+// returns the secret to mail. Only its hash is stored.
+func issueUserToken(db *gorm.DB, userID uuid.UUID, purpose string, ttl time.Duration) (string, error) {
+	secret, err := utils.GenerateSecret()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.UserToken{}).
+			Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+			Update("used_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.UserToken{
+			UserID:    userID,
+			Purpose:   purpose,
+			TokenHash: utils.HashSecret(secret),
+			ExpiresAt: now.Add(ttl),
+			CreatedAt: now,
+		}).Error
+	})
+	return secret, err
+}
+
+// consumeUserToken marks the token matching secret as used and returns it.
+// It must run inside a transaction so the token is only spent if the caller's
+// change is committed.
+func consumeUserToken(tx *gorm.DB, secret, purpose string) (models.UserToken, error) {
+	var token models.UserToken
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&token, "token_hash = ? AND purpose = ?", utils.HashSecret(secret), purpose).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return token, errInvalidUserToken
+	}
+	if err != nil {
+		return token, err
+	}
+
+	now := time.Now()
+	if token.UsedAt != nil || !now.Before(token.ExpiresAt) {
+		return token, errInvalidUserToken
+	}
+
+	token.UsedAt = &now
+	return token, tx.Model(&token).Update("used_at", now).Error
+}
+
+// sendVerificationMail mails user a link to verify their email address.
+// Failures are logged; the user can ask for the mail again.
+func sendVerificationMail(db *gorm.DB, config initializers.Config, user models.User) {
+	ttl := config.EmailVerificationExpiresIn
+	if ttl == 0 {
+		ttl = 24 * time.Hour
+	}
+
+	secret, err := issueUserToken(db, user.ID, models.TokenPurposeEmailVerification, ttl)
+	if err != nil {
+		log.Printf("Failed to create verification token for %s: %v", user.Email, err)
+		return
+	}
+
+	err = config.Mailer().Send(utils.Mail{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nConfirm your email address by opening the link below. It expires in %s.\n\n%s/verify-email?token=%s\n",
+			user.Name, ttl, config.ClientOrigin, secret),
+	})
+	if err != nil {
+		log.Printf("Failed to send verification mail to %s: %v", user.Email, err)
+	}
+}
+
+// sendPasswordResetMail mails user a link to choose a new password.
+func sendPasswordResetMail(db *gorm.DB, config initializers.Config, user models.User) {
+	ttl := config.PasswordResetExpiresIn
+	if ttl == 0 {
+		ttl = time.Hour
+	}
+
+	secret, err := issueUserToken(db, user.ID, models.TokenPurposePasswordReset, ttl)
+	if err != nil {
+		log.Printf("Failed to create password reset token for %s: %v", user.Email, err)
+		return
+	}
+
+	err = config.Mailer().Send(utils.Mail{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nSomeone asked to reset your password. If it was you, open the link below within %s to choose a new one. Otherwise ignore this mail.\n\n%s/reset-password?token=%s\n",
+			user.Name, ttl, config.ClientOrigin, secret),
+	})
+	if err != nil {
+		log.Printf("Failed to send password reset mail to %s: %v", user.Email, err)
+	}
+}
@@ -0,0 +1,31 @@
+// controllers/well_known.controller.go
+
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/suidevv/tableye-api/utils"
+)
+
+type WellKnownController struct {
+	Tokens *utils.TokenService
+}
+
+func NewWellKnownController(Tokens *utils.TokenService) WellKnownController {
+	return WellKnownController{Tokens}
+}
+
+// FindJWKS godoc
+// @Summary Access token signing keys
+// @Description Returns the public keys that verify access tokens as a JSON Web Key Set. During a key rotation both the new and the retired keys are listed; match a token to its key by the "kid" header.
+// @Tags authentication
+// @Produce json
+// @Success 200 {object} utils.JSONWebKeySet
+// @Failure 500 {object} map[string]interface{}
+// @Router /.well-known/jwks.json [get]
+func (wc *WellKnownController) FindJWKS(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, wc.Tokens.AccessTokenJWKS())
+}
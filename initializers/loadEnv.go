@@ -13,14 +13,55 @@ type Config struct {
 	Domain       string `mapstructure:"DOMAIN"`
 	ClientOrigin string `mapstructure:"CLIENT_ORIGIN"`
 
-	AccessTokenPrivateKey  string        `mapstructure:"ACCESS_TOKEN_PRIVATE_KEY"`
-	AccessTokenPublicKey   string        `mapstructure:"ACCESS_TOKEN_PUBLIC_KEY"`
-	RefreshTokenPrivateKey string        `mapstructure:"REFRESH_TOKEN_PRIVATE_KEY"`
-	RefreshTokenPublicKey  string        `mapstructure:"REFRESH_TOKEN_PUBLIC_KEY"`
-	AccessTokenExpiresIn   time.Duration `mapstructure:"ACCESS_TOKEN_EXPIRED_IN"`
-	RefreshTokenExpiresIn  time.Duration `mapstructure:"REFRESH_TOKEN_EXPIRED_IN"`
-	AccessTokenMaxAge      int           `mapstructure:"ACCESS_TOKEN_MAXAGE"`
-	RefreshTokenMaxAge     int           `mapstructure:"REFRESH_TOKEN_MAXAGE"`
+	AccessTokenPrivateKey  string `mapstructure:"ACCESS_TOKEN_PRIVATE_KEY"`
+	AccessTokenPublicKey   string `mapstructure:"ACCESS_TOKEN_PUBLIC_KEY"`
+	RefreshTokenPrivateKey string `mapstructure:"REFRESH_TOKEN_PRIVATE_KEY"`
+	RefreshTokenPublicKey  string `mapstructure:"REFRESH_TOKEN_PUBLIC_KEY"`
+
+	// Comma-separated public keys of rotated-out signing keys
+	AccessTokenRetiredPublicKeys  string `mapstructure:"ACCESS_TOKEN_RETIRED_PUBLIC_KEYS"`
+	RefreshTokenRetiredPublicKeys string `mapstructure:"REFRESH_TOKEN_RETIRED_PUBLIC_KEYS"`
+
+	// Keys can be read from PEM files instead. A keys directory wins over key
+	// files, which win over the base64 keys above.
+	AccessTokenKeysDir         string `mapstructure:"ACCESS_TOKEN_KEYS_DIR"`
+	AccessTokenPrivateKeyFile  string `mapstructure:"ACCESS_TOKEN_PRIVATE_KEY_FILE"`
+	AccessTokenPublicKeyFiles  string `mapstructure:"ACCESS_TOKEN_PUBLIC_KEY_FILES"`
+	RefreshTokenKeysDir        string `mapstructure:"REFRESH_TOKEN_KEYS_DIR"`
+	RefreshTokenPrivateKeyFile string `mapstructure:"REFRESH_TOKEN_PRIVATE_KEY_FILE"`
+	RefreshTokenPublicKeyFiles string `mapstructure:"REFRESH_TOKEN_PUBLIC_KEY_FILES"`
+
+	// Mail is sent over SMTP when SMTP_HOST is set, otherwise it is written to
+	// MAIL_LOG_FILE or the log
+	SMTPHost     string `mapstructure:"SMTP_HOST"`
+	SMTPPort     int    `mapstructure:"SMTP_PORT"`
+	SMTPUsername string `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword string `mapstructure:"SMTP_PASSWORD"`
+	MailFrom     string `mapstructure:"MAIL_FROM"`
+	MailLogFile  string `mapstructure:"MAIL_LOG_FILE"`
+
+	EmailVerificationExpiresIn time.Duration `mapstructure:"EMAIL_VERIFICATION_EXPIRED_IN"`
+	PasswordResetExpiresIn     time.Duration `mapstructure:"PASSWORD_RESET_EXPIRED_IN"`
+
+	// Failed sign-ins beyond the free attempts block the email address or
+	// client IP, with the delay doubling up to LOGIN_MAX_DELAY. Verification
+	// and reset mail requests are limited the same way, counted separately.
+	LoginAccountFreeAttempts int           `mapstructure:"LOGIN_ACCOUNT_FREE_ATTEMPTS"`
+	LoginIPFreeAttempts      int           `mapstructure:"LOGIN_IP_FREE_ATTEMPTS"`
+	LoginBaseDelay           time.Duration `mapstructure:"LOGIN_BASE_DELAY"`
+	LoginMaxDelay            time.Duration `mapstructure:"LOGIN_MAX_DELAY"`
+	LoginFailureWindow       time.Duration `mapstructure:"LOGIN_FAILURE_WINDOW"`
+
+	// Comma-separated roles that cannot use the API until they enrol in TOTP
+	// two-factor authentication
+	TwoFactorRequiredRoles      string        `mapstructure:"TWO_FACTOR_REQUIRED_ROLES"`
+	TOTPIssuer                  string        `mapstructure:"TOTP_ISSUER"`
+	TwoFactorChallengeExpiresIn time.Duration `mapstructure:"TWO_FACTOR_CHALLENGE_EXPIRED_IN"`
+
+	AccessTokenExpiresIn  time.Duration `mapstructure:"ACCESS_TOKEN_EXPIRED_IN"`
+	RefreshTokenExpiresIn time.Duration `mapstructure:"REFRESH_TOKEN_EXPIRED_IN"`
+	AccessTokenMaxAge     int           `mapstructure:"ACCESS_TOKEN_MAXAGE"`
+	RefreshTokenMaxAge    int           `mapstructure:"REFRESH_TOKEN_MAXAGE"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
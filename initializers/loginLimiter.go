@@ -0,0 +1,60 @@
+package initializers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/suidevv/tableye-api/utils"
+)
+
+var (
+	loginLimitersOnce sync.Once
+	accountLimiter    utils.LoginLimiter
+	ipLimiter         utils.LoginLimiter
+)
+
+// SetLoginLimiters replaces the in-process limiters, for example with ones
+// backed by a store shared between API instances. Call it before serving.
+func SetLoginLimiters(accounts, ips utils.LoginLimiter) {
+	loginLimitersOnce.Do(func() {})
+	accountLimiter, ipLimiter = accounts, ips
+}
+
+// AccountLimiter returns the limiter for failed sign-ins per email address.
+func (config *Config) AccountLimiter() utils.LoginLimiter {
+	config.initLoginLimiters()
+	return accountLimiter
+}
+
+// IPLimiter returns the limiter for failed sign-ins per client IP. It allows
+// more attempts than the account limiter so users behind a shared address
+// are not locked out by each other's typos.
+func (config *Config) IPLimiter() utils.LoginLimiter {
+	config.initLoginLimiters()
+	return ipLimiter
+}
+
+func (config *Config) initLoginLimiters() {
+	loginLimitersOnce.Do(func() {
+		accountLimiter = utils.NewMemoryLoginLimiter(utils.LoginPolicy{
+			FreeAttempts: orDefault(config.LoginAccountFreeAttempts, 5),
+			BaseDelay:    orDefault(config.LoginBaseDelay, 30*time.Second),
+			MaxDelay:     orDefault(config.LoginMaxDelay, time.Hour),
+			Window:       orDefault(config.LoginFailureWindow, time.Hour),
+		})
+		ipLimiter = utils.NewMemoryLoginLimiter(utils.LoginPolicy{
+			FreeAttempts: orDefault(config.LoginIPFreeAttempts, 20),
+			BaseDelay:    orDefault(config.LoginBaseDelay, 30*time.Second),
+			MaxDelay:     orDefault(config.LoginMaxDelay, time.Hour),
+			Window:       orDefault(config.LoginFailureWindow, time.Hour),
+		})
+	})
+}
+
+func orDefault[T comparable](value, fallback T) T {
+	var zero T
+	if value == zero {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,20 @@
+package initializers
+
+import (
+	"github.com/suidevv/tableye-api/utils"
+)
+
+// Mailer returns an SMTP mailer when SMTP is configured and a mailer that
+// only logs otherwise.
+func (config *Config) Mailer() utils.Mailer {
+	if config.SMTPHost == "" {
+		return utils.LogMailer{Path: config.MailLogFile}
+	}
+	return utils.SMTPMailer{
+		Host:     config.SMTPHost,
+		Port:     config.SMTPPort,
+		Username: config.SMTPUsername,
+		Password: config.SMTPPassword,
+		From:     config.MailFrom,
+	}
+}
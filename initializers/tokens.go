@@ -0,0 +1,59 @@
+package initializers
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/suidevv/tableye-api/utils"
+)
+
+var Tokens *utils.TokenService
+
+// LoadTokenService builds the token service once at startup, so requests do
+// not read and parse the keys again. Rotating keys takes a restart.
+func LoadTokenService(config *Config) {
+	var err error
+
+	Tokens, err = config.TokenService()
+	if err != nil {
+		log.Fatal("Failed to load token keys: ", err)
+	}
+	fmt.Println("🚀 Loaded token keys")
+}
+
+// TokenService builds the service that issues and validates the API's tokens
+// from the configured keys. Retired public keys keep tokens signed before a
+// key rotation valid until they expire.
+func (config *Config) TokenService() (*utils.TokenService, error) {
+	accessKeys, err := config.AccessTokenKeySource().KeyRing()
+	if err != nil {
+		return nil, err
+	}
+
+	refreshKeys, err := config.RefreshTokenKeySource().KeyRing()
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.NewTokenService(accessKeys, refreshKeys), nil
+}
+
+func (config *Config) AccessTokenKeySource() utils.KeySource {
+	return keySource(config.AccessTokenKeysDir, config.AccessTokenPrivateKeyFile, config.AccessTokenPublicKeyFiles,
+		config.AccessTokenPrivateKey, config.AccessTokenPublicKey, config.AccessTokenRetiredPublicKeys)
+}
+
+func (config *Config) RefreshTokenKeySource() utils.KeySource {
+	return keySource(config.RefreshTokenKeysDir, config.RefreshTokenPrivateKeyFile, config.RefreshTokenPublicKeyFiles,
+		config.RefreshTokenPrivateKey, config.RefreshTokenPublicKey, config.RefreshTokenRetiredPublicKeys)
+}
+
+func keySource(keysDir, privateKeyFile, publicKeyFiles, privateKey, publicKey, retiredPublicKeys string) utils.KeySource {
+	switch {
+	case keysDir != "":
+		return utils.KeyDirectory{Dir: keysDir}
+	case privateKeyFile != "":
+		return utils.KeyFiles{PrivateKeyFile: privateKeyFile, PublicKeyFiles: utils.SplitKeys(publicKeyFiles)}
+	}
+	return utils.EnvKeys{PrivateKey: privateKey, PublicKeys: append([]string{publicKey}, utils.SplitKeys(retiredPublicKeys)...)}
+}
@@ -0,0 +1,28 @@
+package initializers
+
+import (
+	"time"
+
+	"github.com/suidevv/tableye-api/utils"
+)
+
+// TwoFactorRequired reports whether users with role must use two-factor
+// authentication.
+func (config *Config) TwoFactorRequired(role string) bool {
+	for _, required := range utils.SplitKeys(config.TwoFactorRequiredRoles) {
+		if required == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (config *Config) TwoFactorIssuer() string {
+	return orDefault(config.TOTPIssuer, "Tableye")
+}
+
+// TwoFactorChallengeTTL is how long a user has to enter their code after
+// their password was accepted.
+func (config *Config) TwoFactorChallengeTTL() time.Duration {
+	return orDefault(config.TwoFactorChallengeExpiresIn, 5*time.Minute)
+}
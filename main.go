@@ -12,6 +12,7 @@ import (
 	"github.com/suidevv/tableye-api/controllers"
 	_ "github.com/suidevv/tableye-api/docs"
 	"github.com/suidevv/tableye-api/initializers"
+	"github.com/suidevv/tableye-api/middleware"
 	"github.com/suidevv/tableye-api/routes"
 )
 
@@ -42,18 +43,30 @@ var (
 	UserRouteController        routes.UserRouteController
 	CasinoController           controllers.CasinoController
 	CasinoRouteController      routes.CasinoRouteController
+	TableController            controllers.TableController
+	TableRouteController       routes.TableRouteController
 	GameController             controllers.GameController
 	GameRouteController        routes.GameRouteController
 	PlayerController           controllers.PlayerController
 	PlayerRouteController      routes.PlayerRouteController
 	DealerController           controllers.DealerController
 	DealerRouteController      routes.DealerRouteController
+	ShiftController            controllers.ShiftController
+	ShiftRouteController       routes.ShiftRouteController
 	GameSummaryController      controllers.GameSummaryController
 	GameSummaryRouteController routes.GameSummaryRouteController
+	RoundController            controllers.RoundController
+	RoundRouteController       routes.RoundRouteController
 	TransactionController      controllers.TransactionController
 	TransactionRouteController routes.TransactionRouteController
+	ReportController           controllers.ReportController
+	ReportRouteController      routes.ReportRouteController
 	AdminController            controllers.AdminController
 	AdminRouteController       routes.AdminRouteController
+	PermissionController       controllers.PermissionController
+	PermissionRouteController  routes.PermissionRouteController
+	WellKnownController        controllers.WellKnownController
+	WellKnownRouteController   routes.WellKnownRouteController
 )
 
 func init() {
@@ -63,8 +76,9 @@ func init() {
 	}
 
 	initializers.ConnectDB(&config)
+	initializers.LoadTokenService(&config)
 
-	AuthController = controllers.NewAuthController(initializers.DB)
+	AuthController = controllers.NewAuthController(initializers.DB, initializers.Tokens)
 	AuthRouteController = routes.NewAuthRouteController(AuthController)
 
 	UserController = controllers.NewUserController(initializers.DB)
@@ -73,6 +87,9 @@ func init() {
 	CasinoController = controllers.NewCasinoController(initializers.DB)
 	CasinoRouteController = routes.NewRouteCasinoController(CasinoController)
 
+	TableController = controllers.NewTableController(initializers.DB)
+	TableRouteController = routes.NewRouteTableController(TableController)
+
 	GameController = controllers.NewGameController(initializers.DB)
 	GameRouteController = routes.NewRouteGameController(GameController)
 
@@ -82,15 +99,30 @@ func init() {
 	DealerController = controllers.NewDealerController(initializers.DB)
 	DealerRouteController = routes.NewRouteDealerController(DealerController)
 
+	ShiftController = controllers.NewShiftController(initializers.DB)
+	ShiftRouteController = routes.NewRouteShiftController(ShiftController)
+
 	GameSummaryController = controllers.NewGameSummaryController(initializers.DB)
 	GameSummaryRouteController = routes.NewRouteGameSummaryController(GameSummaryController)
 
+	RoundController = controllers.NewRoundController(initializers.DB)
+	RoundRouteController = routes.NewRouteRoundController(RoundController)
+
 	TransactionController = controllers.NewTransactionController(initializers.DB)
 	TransactionRouteController = routes.NewRouteTransactionController(TransactionController)
 
+	ReportController = controllers.NewReportController(initializers.DB)
+	ReportRouteController = routes.NewRouteReportController(ReportController)
+
 	AdminController = controllers.NewAdminController(initializers.DB)
 	AdminRouteController = routes.NewRouteAdminController(AdminController)
 
+	PermissionController = controllers.NewPermissionController(initializers.DB)
+	PermissionRouteController = routes.NewRoutePermissionController(PermissionController)
+
+	WellKnownController = controllers.NewWellKnownController(initializers.Tokens)
+	WellKnownRouteController = routes.NewRouteWellKnownController(WellKnownController)
+
 	server = gin.Default()
 }
 
@@ -119,9 +151,12 @@ func main() {
 	corsConfig.AllowCredentials = true
 
 	server.Use(cors.New(corsConfig))
+	server.Use(middleware.RequestID())
 
 	server.StaticFile("", "templates/index.html")
 
+	WellKnownRouteController.WellKnownRoute(&server.RouterGroup)
+
 	router := server.Group("/api")
 
 	// Health check endpoint
@@ -134,12 +169,17 @@ func main() {
 	AuthRouteController.AuthRoute(router)
 	UserRouteController.UserRoute(router)
 	CasinoRouteController.CasinoRoute(router)
+	TableRouteController.TableRoute(router)
 	GameRouteController.GameRoute(router)
 	PlayerRouteController.PlayerRoute(router)
 	DealerRouteController.DealerRoute(router)
+	ShiftRouteController.ShiftRoute(router)
 	GameSummaryRouteController.GameSummaryRoute(router)
+	RoundRouteController.RoundRoute(router)
 	TransactionRouteController.TransactionRoute(router)
+	ReportRouteController.ReportRoute(router)
 	AdminRouteController.AdminRoute(router)
+	PermissionRouteController.PermissionRoute(router)
 
 	log.Fatal(server.Run(":" + config.ServerPort))
 }
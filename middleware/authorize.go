@@ -6,9 +6,13 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/suidevv/tableye-api/initializers"
+	"github.com/suidevv/tableye-api/models"
 )
 
-func AuthorizeRoles(roles ...string) gin.HandlerFunc {
+// RequirePermission lets the request through when the current user's role
+// has been granted permission. It must run after DeserializeUser.
+func RequirePermission(permission string) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		userRole, exists := ctx.Get("userRole")
 		if !exists {
@@ -22,13 +26,19 @@ func AuthorizeRoles(roles ...string) gin.HandlerFunc {
 			return
 		}
 
-		for _, allowedRole := range roles {
-			if role == allowedRole {
-				ctx.Next()
-				return
-			}
+		var count int64
+		if err := initializers.DB.Model(&models.RolePermission{}).
+			Where("role = ? AND permission = ?", role, permission).
+			Count(&count).Error; err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to check permissions"})
+			return
+		}
+
+		if count == 0 {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"status": "fail", "message": "Access denied"})
+			return
 		}
 
-		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"status": "fail", "message": "Access denied"})
+		ctx.Next()
 	}
 }
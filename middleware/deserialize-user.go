@@ -1,9 +1,9 @@
 package middleware
 
 import (
-	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/suidevv/tableye-api/initializers"
@@ -11,7 +11,19 @@ import (
 	"github.com/suidevv/tableye-api/utils"
 )
 
+// DeserializeUser loads the user of the request's access token. Users whose
+// role requires two-factor authentication are refused until they enrol.
 func DeserializeUser() gin.HandlerFunc {
+	return deserializeUser(true)
+}
+
+// DeserializeUserForEnrolment is DeserializeUser for the routes a user needs
+// to enrol in two-factor authentication, so it lets unenrolled users through.
+func DeserializeUserForEnrolment() gin.HandlerFunc {
+	return deserializeUser(false)
+}
+
+func deserializeUser(enforceTwoFactor bool) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var access_token string
 		cookie, err := ctx.Cookie("access_token")
@@ -31,19 +43,33 @@ func DeserializeUser() gin.HandlerFunc {
 		}
 
 		config, _ := initializers.LoadConfig(".")
-		sub, err := utils.ValidateToken(access_token, config.AccessTokenPublicKey)
+		claims, err := initializers.Tokens.ValidateToken(utils.TokenTypeAccess, access_token)
 		if err != nil {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"status": "fail", "message": err.Error()})
 			return
 		}
 
 		var user models.User
-		result := initializers.DB.First(&user, "id = ?", fmt.Sprint(sub))
+		result := initializers.DB.First(&user, "id = ?", claims.Subject)
 		if result.Error != nil {
 			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"status": "fail", "message": "the user belonging to this token no logger exists"})
 			return
 		}
 
+		// Access tokens die with the session they were issued for
+		if claims.SessionID != "" {
+			var session models.Session
+			if err := initializers.DB.First(&session, "id = ? AND user_id = ?", claims.SessionID, user.ID).Error; err != nil || !session.IsActive(time.Now()) {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"status": "fail", "message": "Your session has ended, please log in again"})
+				return
+			}
+		}
+
+		if enforceTwoFactor && !user.TwoFactorEnabled && config.TwoFactorRequired(user.Role) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"status": "fail", "message": "Two-factor authentication is required for your role, enrol at /api/auth/2fa/setup"})
+			return
+		}
+
 		ctx.Set("currentUser", user)
 		ctx.Set("userRole", user.Role)
 		ctx.Next()
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestID tags each request with the caller's X-Request-ID, or a new one,
+// and echoes it in the response so log lines and audit events can be matched.
+func RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader("X-Request-ID")
+		if requestID == "" || len(requestID) > 100 {
+			requestID = uuid.New().String()
+		}
+
+		ctx.Set("requestID", requestID)
+		ctx.Header("X-Request-ID", requestID)
+		ctx.Next()
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/suidevv/tableye-api/initializers"
+	"github.com/suidevv/tableye-api/models"
+	"gorm.io/gorm"
+)
+
+// ScopeCasinos stores the casinos the current user is linked to as
+// "casinoScope". It must run after DeserializeUser. Controllers use the scope
+// to filter every casino-owned record, so a route without it sees nothing.
+func ScopeCasinos() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		currentUser, exists := ctx.Get("currentUser")
+		if !exists {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"status": "fail", "message": "Unauthorized"})
+			return
+		}
+		scope, err := LoadCasinoScope(initializers.DB, currentUser.(models.User))
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to load casino access"})
+			return
+		}
+
+		ctx.Set("casinoScope", scope)
+		ctx.Next()
+	}
+}
+
+// LoadCasinoScope returns the casinos user is linked to. Admins are
+// unrestricted; other roles see the casinos they work at or own.
+func LoadCasinoScope(db *gorm.DB, user models.User) (models.CasinoScope, error) {
+	scope := models.CasinoScope{}
+	var err error
+	switch user.Role {
+	case "admin":
+		scope.Unrestricted = true
+	case "dealer":
+		err = db.Table("casino_dealers cd").
+			Joins("JOIN dealers d ON d.id = cd.dealer_id").
+			Where("d.user_id = ?", user.ID).
+			Pluck("cd.casino_id", &scope.CasinoIDs).Error
+	case "casino_owner":
+		err = db.Table("casino_owners").
+			Where("user_id = ?", user.ID).
+			Pluck("casino_id", &scope.CasinoIDs).Error
+	}
+	if err != nil {
+		return scope, err
+	}
+
+	if scope.CasinoIDs == nil {
+		scope.CasinoIDs = []uuid.UUID{}
+	}
+	return scope, nil
+}
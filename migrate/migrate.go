@@ -22,6 +22,73 @@ func dropDatabase() error {
 	return nil
 }
 
+// seedPermissions creates missing permissions and grants each new one to its
+// default roles. Existing permissions are left alone so grants changed through
+// the admin endpoints survive a re-run.
+func seedPermissions() error {
+	defaultRoles := make(map[string][]string)
+	for role, permissions := range models.DefaultRolePermissions {
+		for _, permission := range permissions {
+			defaultRoles[permission] = append(defaultRoles[permission], role)
+		}
+	}
+
+	for _, permission := range models.Permissions {
+		result := initializers.DB.Exec("INSERT INTO permissions (name, description) VALUES (?, ?) ON CONFLICT (name) DO NOTHING", permission.Name, permission.Description)
+		if result.Error != nil {
+			return fmt.Errorf("failed to create permission %s: %v", permission.Name, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		for _, role := range defaultRoles[permission.Name] {
+			if err := initializers.DB.Exec("INSERT INTO role_permissions (role, permission) VALUES (?, ?) ON CONFLICT DO NOTHING", role, permission.Name).Error; err != nil {
+				return fmt.Errorf("failed to grant %s to %s: %v", permission.Name, role, err)
+			}
+		}
+	}
+	return nil
+}
+
+// moneyColumns are the columns holding amounts of money, by table.
+var moneyColumns = map[string][]string{
+	"games":          {"min_bet", "max_bet"},
+	"tables":         {"min_bet", "max_bet"},
+	"game_summaries": {"total_pot", "highest_bet"},
+	"players":        {"total_winnings"},
+	"transactions":   {"amount"},
+}
+
+// convertMoneyColumns converts money columns that are still double precision,
+// or narrower decimals, to decimal(14,2), rounding any float drift to the cent.
+// Converting rewrites and locks the table, so columns already converted are
+// left alone.
+func convertMoneyColumns() error {
+	for table, columns := range moneyColumns {
+		for _, column := range columns {
+			var converted bool
+			if err := initializers.DB.Raw(`SELECT EXISTS (
+				SELECT 1 FROM information_schema.columns
+				WHERE table_schema = current_schema() AND table_name = ? AND column_name = ?
+					AND data_type = 'numeric' AND numeric_precision = 14 AND numeric_scale = 2)`, table, column).
+				Scan(&converted).Error; err != nil {
+				return fmt.Errorf("failed to inspect %s.%s: %v", table, column, err)
+			}
+			if converted {
+				continue
+			}
+
+			query := fmt.Sprintf("ALTER TABLE %[1]s ALTER COLUMN %[2]s TYPE decimal(14,2) USING ROUND(%[2]s::numeric, 2)", table, column)
+			if err := initializers.DB.Exec(query).Error; err != nil {
+				return fmt.Errorf("failed to convert %s.%s: %v", table, column, err)
+			}
+			fmt.Printf("👍 Converted %s.%s to decimal(14,2)\n", table, column)
+		}
+	}
+	return nil
+}
+
 func init() {
 	config, err := initializers.LoadConfig(".")
 	if err != nil {
@@ -43,17 +110,33 @@ func main() {
 
 	if err := initializers.DB.AutoMigrate(
 		&models.User{},
+		&models.Session{},
+		&models.UserToken{},
+		&models.SecurityEvent{},
+		&models.RecoveryCode{},
 		&models.Casino{},
 		&models.Game{},
+		&models.Table{},
 		&models.Dealer{},
+		&models.Shift{},
+		&models.ShiftBreak{},
 		&models.Player{},
 		&models.GameSummary{},
+		&models.Round{},
 		&models.Transaction{},
 		&models.Admin{}, // Add the new Admin model
+		&models.Permission{},
+		&models.RolePermission{},
+		&models.AuditEvent{},
+		&models.ExchangeRate{},
 	); err != nil {
 		log.Fatal("Failed to migrate database: ", err)
 	}
 
+	if err := convertMoneyColumns(); err != nil {
+		log.Fatal("Failed to convert money columns: ", err)
+	}
+
 	// Create many-to-many relationship tables and add indexes
 	queries := []string{
 		`CREATE TABLE IF NOT EXISTS casino_dealers (
@@ -70,6 +153,12 @@ func main() {
 		)`,
 		`CREATE INDEX IF NOT EXISTS idx_casino_games_casino_id ON casino_games(casino_id)`,
 		`CREATE INDEX IF NOT EXISTS idx_casino_games_game_id ON casino_games(game_id)`,
+		`CREATE TABLE IF NOT EXISTS casino_owners (
+			casino_id UUID REFERENCES casinos(id) ON DELETE CASCADE,
+			user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+			PRIMARY KEY (casino_id, user_id)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_casino_owners_user_id ON casino_owners(user_id)`,
 		`CREATE TABLE IF NOT EXISTS game_players (
 			game_summary_id UUID REFERENCES game_summaries(id) ON DELETE CASCADE,
 			player_id UUID REFERENCES players(id) ON DELETE CASCADE,
@@ -82,10 +171,44 @@ func main() {
 		`CREATE INDEX IF NOT EXISTS idx_game_summaries_game_id ON game_summaries(game_id)`,
 		`CREATE INDEX IF NOT EXISTS idx_game_summaries_casino_id ON game_summaries(casino_id)`,
 		`CREATE INDEX IF NOT EXISTS idx_game_summaries_dealer_id ON game_summaries(dealer_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_game_summaries_table_id ON game_summaries(table_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_game_summaries_start_time ON game_summaries(start_time)`,
+		`CREATE INDEX IF NOT EXISTS idx_shifts_dealer_id_status ON shifts(dealer_id, status)`,
+		`CREATE INDEX IF NOT EXISTS idx_shifts_casino_id ON shifts(casino_id)`,
+		// A dealer is clocked in to at most one shift, even under concurrent clock-ins
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_shifts_one_active ON shifts(dealer_id) WHERE status = 'active'`,
+		`CREATE INDEX IF NOT EXISTS idx_shift_breaks_shift_id ON shift_breaks(shift_id)`,
 		`CREATE INDEX IF NOT EXISTS idx_transactions_game_summary_id ON transactions(game_summary_id)`,
 		`CREATE INDEX IF NOT EXISTS idx_transactions_player_id ON transactions(player_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_transactions_round_id ON transactions(round_id)`,
 		// New index for the role column in the users table
 		`CREATE INDEX IF NOT EXISTS idx_users_role ON users(role)`,
+		// Map free-form game summary statuses onto the lifecycle states
+		`UPDATE game_summaries SET status = 'in_progress' WHERE status = 'In Progress'`,
+		`UPDATE game_summaries SET status = 'completed' WHERE status = 'Completed'`,
+		// The audit log is append-only, even for someone with direct database access
+		`CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit_events is append-only';
+		END;
+		$$ LANGUAGE plpgsql`,
+		`DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events`,
+		`CREATE TRIGGER audit_events_append_only BEFORE UPDATE OR DELETE ON audit_events
+			FOR EACH ROW EXECUTE FUNCTION audit_events_append_only()`,
+		// Booked transactions are corrected with reversal entries, never edited.
+		// Only soft deletes and restores may touch a row.
+		`CREATE OR REPLACE FUNCTION transactions_immutable() RETURNS trigger AS $$
+		BEGIN
+			IF (NEW.game_summary_id, NEW.player_id, NEW.round_id, NEW.amount, NEW.currency, NEW.type, NEW.outcome, NEW.reverses_id, NEW.corrects_id, NEW.reason, NEW.created_at)
+				IS DISTINCT FROM (OLD.game_summary_id, OLD.player_id, OLD.round_id, OLD.amount, OLD.currency, OLD.type, OLD.outcome, OLD.reverses_id, OLD.corrects_id, OLD.reason, OLD.created_at) THEN
+				RAISE EXCEPTION 'transactions cannot be edited, book a correction instead';
+			END IF;
+			RETURN NEW;
+		END;
+		$$ LANGUAGE plpgsql`,
+		`DROP TRIGGER IF EXISTS transactions_immutable ON transactions`,
+		`CREATE TRIGGER transactions_immutable BEFORE UPDATE ON transactions
+			FOR EACH ROW EXECUTE FUNCTION transactions_immutable()`,
 	}
 
 	for _, query := range queries {
@@ -94,5 +217,9 @@ func main() {
 		}
 	}
 
+	if err := seedPermissions(); err != nil {
+		log.Fatal("Failed to seed permissions: ", err)
+	}
+
 	fmt.Println("👍 Migration complete")
 }
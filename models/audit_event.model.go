@@ -0,0 +1,77 @@
+package models
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// What an audit event did to its entity.
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+)
+
+// AuditEvent is one change made through the API. Events are append-only and
+// form a hash chain: Hash covers the event and the Hash of the event with the
+// previous Sequence, so editing or removing an event breaks every later hash.
+type AuditEvent struct {
+	ID         uuid.UUID       `gorm:"type:uuid;primary_key" json:"id"`
+	Sequence   int64           `gorm:"not null;uniqueIndex" json:"sequence"`
+	ActorID    *uuid.UUID      `gorm:"type:uuid;index" json:"actor_id,omitempty"`
+	ActorRole  string          `gorm:"type:varchar(20)" json:"actor_role,omitempty"`
+	Action     string          `gorm:"type:varchar(20);not null;index" json:"action"`
+	EntityType string          `gorm:"type:varchar(50);not null;index:idx_audit_events_entity" json:"entity_type"`
+	EntityID   string          `gorm:"type:varchar(100);not null;index:idx_audit_events_entity" json:"entity_id"`
+	Changes    json.RawMessage `gorm:"type:jsonb" json:"changes,omitempty"`
+	ClientIP   string          `gorm:"type:varchar(45)" json:"client_ip,omitempty"`
+	RequestID  string          `gorm:"type:varchar(100)" json:"request_id,omitempty"`
+	PrevHash   string          `gorm:"type:varchar(64);not null" json:"prev_hash"`
+	Hash       string          `gorm:"type:varchar(64);not null" json:"hash"`
+	CreatedAt  time.Time       `gorm:"not null;index" json:"created_at"`
+}
+
+// ComputeHash returns the hash the event should carry. Changes is hashed in a
+// canonical form because Postgres does not keep jsonb as it was written.
+func (e *AuditEvent) ComputeHash() (string, error) {
+	var changes interface{}
+	if len(e.Changes) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(e.Changes))
+		decoder.UseNumber()
+		if err := decoder.Decode(&changes); err != nil {
+			return "", err
+		}
+	}
+
+	var actorID string
+	if e.ActorID != nil {
+		actorID = e.ActorID.String()
+	}
+
+	content, err := json.Marshal([]interface{}{
+		e.PrevHash,
+		e.Sequence,
+		e.ID.String(),
+		actorID,
+		e.ActorRole,
+		e.Action,
+		e.EntityType,
+		e.EntityID,
+		changes,
+		e.ClientIP,
+		e.RequestID,
+		e.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
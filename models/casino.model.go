@@ -6,6 +6,8 @@ import (
 	"github.com/google/uuid"
 )
 
+// Casino is a gaming venue. Currency is the ISO 4217 code every amount booked
+// there is in; it cannot change once the casino has tables or sessions.
 type Casino struct {
 	ID            uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id,omitempty"`
 	Name          string    `gorm:"type:varchar(255);not null;uniqueIndex" json:"name,omitempty"`
@@ -18,6 +20,7 @@ type Casino struct {
 	MaxCapacity   int       `gorm:"not null" json:"max_capacity,omitempty"`
 	Status        string    `gorm:"type:varchar(50);not null" json:"status,omitempty"`
 	Rating        float32   `json:"rating,omitempty"`
+	Currency      string    `gorm:"type:varchar(3);not null;default:'EUR'" json:"currency,omitempty"`
 	CreatedAt     time.Time `gorm:"not null" json:"created_at,omitempty"`
 	UpdatedAt     time.Time `gorm:"not null" json:"updated_at,omitempty"`
 }
@@ -32,6 +35,7 @@ type CreateCasinoRequest struct {
 	PhoneNumber   string `json:"phone_number"`
 	MaxCapacity   int    `json:"max_capacity" binding:"required"`
 	Status        string `json:"status" binding:"required"`
+	Currency      string `json:"currency,omitempty" binding:"omitempty,iso4217" example:"EUR"`
 }
 
 type UpdateCasinoRequest struct {
@@ -45,6 +49,7 @@ type UpdateCasinoRequest struct {
 	MaxCapacity   int     `json:"max_capacity,omitempty"`
 	Status        string  `json:"status,omitempty"`
 	Rating        float32 `json:"rating,omitempty"`
+	Currency      string  `json:"currency,omitempty" binding:"omitempty,iso4217" example:"EUR"`
 }
 
 type CasinoResponse struct {
@@ -59,6 +64,7 @@ type CasinoResponse struct {
 	MaxCapacity   int       `json:"max_capacity,omitempty"`
 	Status        string    `json:"status,omitempty"`
 	Rating        float32   `json:"rating,omitempty"`
+	Currency      string    `json:"currency,omitempty"`
 	CreatedAt     time.Time `json:"created_at,omitempty"`
 	UpdatedAt     time.Time `json:"updated_at,omitempty"`
 }
@@ -0,0 +1,24 @@
+package models
+
+import "github.com/google/uuid"
+
+// CasinoScope lists the casinos a user may see and change. Admins are
+// unrestricted; dealers are limited to the casinos they are assigned to and
+// casino owners to the casinos they own.
+type CasinoScope struct {
+	Unrestricted bool
+	CasinoIDs    []uuid.UUID
+}
+
+// Allows reports whether the scope includes the casino.
+func (s CasinoScope) Allows(casinoID uuid.UUID) bool {
+	if s.Unrestricted {
+		return true
+	}
+	for _, id := range s.CasinoIDs {
+		if id == casinoID {
+			return true
+		}
+	}
+	return false
+}
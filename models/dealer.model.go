@@ -3,6 +3,7 @@
 package models
 
 import (
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -27,11 +28,11 @@ type CreateDealerRequest struct {
 	Status     string `json:"status" binding:"required"`
 }
 
+// UpdateDealerRequest leaves out GamesDealt and Rating, which are derived from
+// the dealer's game summaries, and LastActiveAt, which is set on clock-in and
+// clock-out.
 type UpdateDealerRequest struct {
-	Status       string    `json:"status,omitempty"`
-	GamesDealt   int       `json:"games_dealt,omitempty"`
-	Rating       float32   `json:"rating,omitempty"`
-	LastActiveAt time.Time `json:"last_active_at,omitempty"`
+	Status string `json:"status,omitempty"`
 }
 
 type DealerResponse struct {
@@ -45,3 +46,34 @@ type DealerResponse struct {
 	CreatedAt    time.Time    `json:"created_at,omitempty"`
 	UpdatedAt    time.Time    `json:"updated_at,omitempty"`
 }
+
+type DealerPerformanceResponse struct {
+	DealerID              uuid.UUID  `json:"dealer_id"`
+	From                  *time.Time `json:"from,omitempty"`
+	To                    *time.Time `json:"to,omitempty"`
+	GamesDealt            int64      `json:"games_dealt"`
+	CompletedSessions     int64      `json:"completed_sessions"`
+	VoidedSessions        int64      `json:"voided_sessions"`
+	RoundsDealt           int64      `json:"rounds_dealt"`
+	HoursDealt            float64    `json:"hours_dealt"`
+	RoundsPerHour         float64    `json:"rounds_per_hour"`
+	AverageSessionMinutes float64    `json:"average_session_minutes"`
+	VoidRate              float64    `json:"void_rate"`
+	Corrections           int64      `json:"corrections"`
+	ErrorRate             float64    `json:"error_rate"`
+	Rating                float32    `json:"rating"`
+}
+
+// TargetRoundsPerHour is the dealing pace that earns the full pace score.
+const TargetRoundsPerHour = 40
+
+// DealerRating scores a dealer from 0 to 5. Pace counts for 50%, capped at
+// TargetRoundsPerHour, sessions that were not voided for 25% and transactions
+// that did not need a correction for the other 25%.
+func DealerRating(roundsPerHour, voidRate, errorRate float64) float32 {
+	pace := math.Min(roundsPerHour/TargetRoundsPerHour, 1)
+	reliability := 1 - math.Min(math.Max(voidRate, 0), 1)
+	accuracy := 1 - math.Min(math.Max(errorRate, 0), 1)
+	rating := 5 * (0.5*math.Max(pace, 0) + 0.25*reliability + 0.25*accuracy)
+	return float32(math.Round(rating*100) / 100)
+}
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExchangeRate is what one unit of Currency is worth in DefaultCurrency, from
+// Date until the next rate of the same currency. Amounts are converted with the
+// rate in force on the day their session started.
+type ExchangeRate struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	Currency  string    `gorm:"type:varchar(3);not null;uniqueIndex:idx_exchange_rates_currency_date" json:"currency"`
+	Date      time.Time `gorm:"type:date;not null;uniqueIndex:idx_exchange_rates_currency_date" json:"date"`
+	Rate      Rate      `gorm:"type:decimal(18,8);not null" json:"rate" swaggertype:"string" example:"0.92"`
+	CreatedAt time.Time `gorm:"not null" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null" json:"updated_at"`
+}
+
+// SetExchangeRateRequest sets the rate of a currency from a date onwards,
+// replacing the rate already set for that date.
+type SetExchangeRateRequest struct {
+	Currency string `json:"currency" binding:"required,iso4217" example:"USD"`
+	Date     string `json:"date" binding:"required" example:"2024-06-01"`
+	Rate     Rate   `json:"rate" binding:"required,gt=0" swaggertype:"string" example:"0.92"`
+}
+
+// rateDecimals is the scale of the rate column.
+const rateDecimals = 8
+
+// Rate is an exchange rate in units of 10^-8, the precision it is stored
+// with, so rates are kept exactly like Money. It travels in JSON as a decimal
+// string such as "0.92".
+type Rate int64
+
+// RateOne is a rate of 1.
+const RateOne Rate = 100_000_000
+
+// ParseRate reads a decimal rate with at most eight decimals, such as "0.92".
+func ParseRate(s string) (Rate, error) {
+	value, err := parseFixed(s, "rate", rateDecimals, false)
+	return Rate(value), err
+}
+
+// String formats the rate without trailing zeros, such as "0.92" or "2".
+func (r Rate) String() string {
+	return strings.TrimSuffix(strings.TrimRight(formatFixed(int64(r), rateDecimals), "0"), ".")
+}
+
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON accepts a decimal string or a plain JSON number, read from its
+// text like Money.
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	text, ok, err := decimalJSON(data, "rate")
+	if err != nil || !ok {
+		return err
+	}
+
+	parsed, err := ParseRate(text)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+func (r Rate) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+func (r *Rate) Scan(value interface{}) error {
+	var err error
+	var parsed int64
+	switch v := value.(type) {
+	case nil:
+	case []byte:
+		parsed, err = parseFixed(string(v), "rate", rateDecimals, true)
+	case string:
+		parsed, err = parseFixed(v, "rate", rateDecimals, true)
+	case int64:
+		parsed = v * int64(RateOne)
+	case float64:
+		parsed = int64(math.Round(v * float64(RateOne)))
+	default:
+		err = fmt.Errorf("cannot scan %T into Rate", value)
+	}
+	*r = Rate(parsed)
+	return err
+}
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// IsCurrencyCode reports whether code looks like an ISO 4217 currency code.
+func IsCurrencyCode(code string) bool {
+	return currencyCode.MatchString(code)
+}
+
+// ConversionRateSQL is a SQL expression for the factor that converts amounts in
+// the currency column currency into target, using the rates in force on the
+// date expression day. It is NULL when a rate is missing. target must be a
+// currency code, it is inlined.
+func ConversionRateSQL(currency, day, target string) string {
+	if !IsCurrencyCode(target) {
+		panic(fmt.Sprintf("invalid currency code %q", target))
+	}
+	return fmt.Sprintf("CASE WHEN %[1]s = '%[3]s' THEN 1 ELSE %[4]s / %[5]s END",
+		currency, day, target, rateSQL(currency, day), rateSQL("'"+target+"'", day))
+}
+
+// rateSQL is the value of one unit of currency in DefaultCurrency on day.
+func rateSQL(currency, day string) string {
+	return fmt.Sprintf(`CASE WHEN %[1]s = '%[3]s' THEN 1 ELSE (
+		SELECT er.rate FROM exchange_rates er WHERE er.currency = %[1]s AND er.date <= (%[2]s)::date
+		ORDER BY er.date DESC LIMIT 1) END`, currency, day, DefaultCurrency)
+}
@@ -1,11 +1,14 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// Game is a game in the catalogue shared by all casinos. Its bet limits are in
+// the reporting currency and converted to a session's currency when checked.
 type Game struct {
 	ID            uuid.UUID     `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id,omitempty"`
 	Name          string        `gorm:"uniqueIndex;not null" json:"name,omitempty"`
@@ -13,8 +16,8 @@ type Game struct {
 	Description   string        `gorm:"type:text" json:"description,omitempty"`
 	MaxPlayers    int           `gorm:"not null" json:"max_players,omitempty"`
 	MinPlayers    int           `gorm:"not null" json:"min_players,omitempty"`
-	MinBet        float64       `gorm:"not null" json:"min_bet,omitempty"`
-	MaxBet        float64       `gorm:"not null" json:"max_bet,omitempty"`
+	MinBet        Money         `gorm:"type:decimal(14,2);not null" json:"min_bet,omitempty"`
+	MaxBet        Money         `gorm:"type:decimal(14,2);not null" json:"max_bet,omitempty"`
 	Casinos       []Casino      `gorm:"many2many:casino_games;" json:"casinos,omitempty"`
 	GameSummaries []GameSummary `gorm:"foreignKey:GameID" json:"game_summaries,omitempty"`
 	CreatedAt     time.Time     `gorm:"not null" json:"created_at,omitempty"`
@@ -22,23 +25,23 @@ type Game struct {
 }
 
 type CreateGameRequest struct {
-	Name        string  `json:"name" binding:"required"`
-	Type        string  `json:"type" binding:"required"`
-	Description string  `json:"description"`
-	MaxPlayers  int     `json:"max_players" binding:"required"`
-	MinPlayers  int     `json:"min_players" binding:"required"`
-	MinBet      float64 `json:"min_bet" binding:"required"`
-	MaxBet      float64 `json:"max_bet" binding:"required"`
+	Name        string `json:"name" binding:"required"`
+	Type        string `json:"type" binding:"required"`
+	Description string `json:"description"`
+	MaxPlayers  int    `json:"max_players" binding:"required"`
+	MinPlayers  int    `json:"min_players" binding:"required"`
+	MinBet      Money  `json:"min_bet" binding:"required" swaggertype:"string" example:"10.00"`
+	MaxBet      Money  `json:"max_bet" binding:"required" swaggertype:"string" example:"1000.00"`
 }
 
 type UpdateGameRequest struct {
-	Name        string  `json:"name,omitempty"`
-	Type        string  `json:"type,omitempty"`
-	Description string  `json:"description,omitempty"`
-	MaxPlayers  int     `json:"max_players,omitempty"`
-	MinPlayers  int     `json:"min_players,omitempty"`
-	MinBet      float64 `json:"min_bet,omitempty"`
-	MaxBet      float64 `json:"max_bet,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	MaxPlayers  int    `json:"max_players,omitempty"`
+	MinPlayers  int    `json:"min_players,omitempty"`
+	MinBet      Money  `json:"min_bet,omitempty" swaggertype:"string" example:"10.00"`
+	MaxBet      Money  `json:"max_bet,omitempty" swaggertype:"string" example:"1000.00"`
 }
 
 type GameResponse struct {
@@ -48,8 +51,81 @@ type GameResponse struct {
 	Description string    `json:"description,omitempty"`
 	MaxPlayers  int       `json:"max_players,omitempty"`
 	MinPlayers  int       `json:"min_players,omitempty"`
-	MinBet      float64   `json:"min_bet,omitempty"`
-	MaxBet      float64   `json:"max_bet,omitempty"`
+	MinBet      Money     `json:"min_bet,omitempty" swaggertype:"string" example:"10.00"`
+	MaxBet      Money     `json:"max_bet,omitempty" swaggertype:"string" example:"1000.00"`
+	Currency    string    `json:"currency,omitempty"`
 	CreatedAt   time.Time `json:"created_at,omitempty"`
 	UpdatedAt   time.Time `json:"updated_at,omitempty"`
 }
+
+// Game rules checked when sessions are opened and bets are recorded. The rule
+// name is returned to clients so they can tell which limit was hit.
+const (
+	RuleMinPlayers      = "min_players"
+	RuleMaxPlayers      = "max_players"
+	RuleMinBet          = "min_bet"
+	RuleMaxBet          = "max_bet"
+	RuleGameNotAtCasino = "game_not_offered_at_casino"
+)
+
+// RuleViolation describes a game rule that a request breaks. Player limits
+// are counts, bet limits are amounts.
+type RuleViolation struct {
+	Rule     string   `json:"rule"`
+	Message  string   `json:"message"`
+	Limit    *float64 `json:"limit,omitempty"`
+	BetLimit *Money   `json:"bet_limit,omitempty" swaggertype:"string"`
+	Currency string   `json:"currency,omitempty"`
+}
+
+func (v *RuleViolation) Error() string {
+	return v.Message
+}
+
+func newLimitViolation(rule, message string, limit float64) *RuleViolation {
+	return &RuleViolation{Rule: rule, Message: fmt.Sprintf("%s %v", message, limit), Limit: &limit}
+}
+
+func newBetLimitViolation(rule, message string, limit Money) *RuleViolation {
+	return &RuleViolation{Rule: rule, Message: fmt.Sprintf("%s %s", message, limit), BetLimit: &limit}
+}
+
+// CheckPlayerCount returns a violation if a session with the given number of
+// players falls outside the game's player limits.
+func (g *Game) CheckPlayerCount(players int) *RuleViolation {
+	if players < g.MinPlayers {
+		return newLimitViolation(RuleMinPlayers, "Number of players is below the game minimum of", float64(g.MinPlayers))
+	}
+	if g.MaxPlayers > 0 && players > g.MaxPlayers {
+		return newLimitViolation(RuleMaxPlayers, "Number of players is above the game maximum of", float64(g.MaxPlayers))
+	}
+	return nil
+}
+
+// BetLimits returns the minimum and maximum bet for the game, taking the
+// table's overrides into account when the session runs on a table.
+func (g *Game) BetLimits(table *Table) (Money, Money) {
+	minBet, maxBet := g.MinBet, g.MaxBet
+	if table != nil {
+		if table.MinBet != nil {
+			minBet = *table.MinBet
+		}
+		if table.MaxBet != nil {
+			maxBet = *table.MaxBet
+		}
+	}
+	return minBet, maxBet
+}
+
+// CheckBetAmount returns a violation if the absolute amount lies outside the
+// bet limits. A zero maximum means the game has no upper limit.
+func CheckBetAmount(amount, minBet, maxBet Money) *RuleViolation {
+	amount = amount.Abs()
+	if amount < minBet {
+		return newBetLimitViolation(RuleMinBet, "Amount is below the minimum bet of", minBet)
+	}
+	if maxBet > 0 && amount > maxBet {
+		return newBetLimitViolation(RuleMaxBet, "Amount is above the maximum bet of", maxBet)
+	}
+	return nil
+}
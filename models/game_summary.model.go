@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type GameSummary struct {
@@ -11,17 +12,55 @@ type GameSummary struct {
 	GameID       uuid.UUID     `gorm:"type:uuid;not null" json:"-"`
 	CasinoID     uuid.UUID     `gorm:"type:uuid;not null" json:"-"`
 	DealerID     uuid.UUID     `gorm:"type:uuid;not null" json:"-"`
+	TableID      *uuid.UUID    `gorm:"type:uuid" json:"-"`
+	Table        *Table        `gorm:"foreignKey:TableID" json:"table,omitempty"`
 	Dealer       Dealer        `gorm:"foreignKey:DealerID" json:"dealer,omitempty"`
 	Players      []Player      `gorm:"many2many:game_players;" json:"players,omitempty"`
 	StartTime    time.Time     `gorm:"not null" json:"start_time,omitempty"`
 	EndTime      time.Time     `json:"end_time,omitempty"`
-	TotalPot     float64       `json:"total_pot,omitempty"`
+	TotalPot     Money         `gorm:"type:decimal(14,2);not null;default:0" json:"total_pot,omitempty"`
 	Status       string        `gorm:"type:varchar(50);not null" json:"status,omitempty"`
 	RoundsPlayed int           `json:"rounds_played,omitempty"`
-	HighestBet   float64       `json:"highest_bet,omitempty"`
+	HighestBet   Money         `gorm:"type:decimal(14,2);not null;default:0" json:"highest_bet,omitempty"`
+	Currency     string        `gorm:"type:varchar(3);not null;default:'EUR'" json:"currency,omitempty"`
 	Transactions []Transaction `gorm:"foreignKey:GameSummaryID" json:"transactions,omitempty"`
+	Rounds       []Round       `gorm:"foreignKey:GameSummaryID" json:"rounds,omitempty"`
 	CreatedAt    time.Time     `gorm:"not null" json:"created_at,omitempty"`
 	UpdatedAt    time.Time     `gorm:"not null" json:"updated_at,omitempty"`
+	// DeletedAt hides the session from every query; its transactions are
+	// deleted with the same timestamp so a restore brings back exactly those.
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// Game summary lifecycle states. A session is scheduled until it is started,
+// may be paused and resumed while running, and ends either completed or voided.
+const (
+	GameSummaryStatusScheduled  = "scheduled"
+	GameSummaryStatusInProgress = "in_progress"
+	GameSummaryStatusPaused     = "paused"
+	GameSummaryStatusCompleted  = "completed"
+	GameSummaryStatusVoided     = "voided"
+)
+
+var gameSummaryTransitions = map[string][]string{
+	GameSummaryStatusScheduled:  {GameSummaryStatusInProgress, GameSummaryStatusVoided},
+	GameSummaryStatusInProgress: {GameSummaryStatusPaused, GameSummaryStatusCompleted, GameSummaryStatusVoided},
+	GameSummaryStatusPaused:     {GameSummaryStatusInProgress, GameSummaryStatusCompleted, GameSummaryStatusVoided},
+}
+
+// CanTransitionTo reports whether the session may move from its current status to the given one.
+func (gs *GameSummary) CanTransitionTo(status string) bool {
+	for _, allowed := range gameSummaryTransitions[gs.Status] {
+		if allowed == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsClosed reports whether the session has reached a final state.
+func (gs *GameSummary) IsClosed() bool {
+	return gs.Status == GameSummaryStatusCompleted || gs.Status == GameSummaryStatusVoided
 }
 
 type CreateGameSummaryRequest struct {
@@ -30,31 +69,33 @@ type CreateGameSummaryRequest struct {
 	StartTime time.Time `json:"start_time" binding:"required"`
 	PlayerIDs []string  `json:"player_ids" binding:"required"`
 	DealerID  string    `json:"dealer_id" binding:"required"`
+	TableID   string    `json:"table_id,omitempty"`
 }
 
+// UpdateGameSummaryRequest only carries client-editable fields. Totals are
+// derived from the session's transactions and the status follows the lifecycle.
 type UpdateGameSummaryRequest struct {
-	EndTime      time.Time `json:"end_time,omitempty"`
-	TotalPot     float64   `json:"total_pot,omitempty"`
-	Status       string    `json:"status,omitempty"`
-	RoundsPlayed int       `json:"rounds_played,omitempty"`
-	HighestBet   float64   `json:"highest_bet,omitempty"`
+	EndTime time.Time `json:"end_time,omitempty"`
 }
 
 type GameSummaryResponse struct {
 	ID           uuid.UUID                 `json:"id,omitempty"`
 	Game         GameResponse              `json:"game,omitempty"`
 	Casino       CasinoResponse            `json:"casino,omitempty"`
+	Table        *TableResponse            `json:"table,omitempty"`
 	StartTime    time.Time                 `json:"start_time,omitempty"`
 	EndTime      time.Time                 `json:"end_time,omitempty"`
 	Players      []PlayerResponse          `json:"players,omitempty"`
 	Dealer       GameSummaryDealerResponse `json:"dealer,omitempty"`
-	TotalPot     float64                   `json:"total_pot,omitempty"`
+	TotalPot     Money                     `json:"total_pot,omitempty" swaggertype:"string" example:"1250.00"`
 	Status       string                    `json:"status,omitempty"`
 	RoundsPlayed int                       `json:"rounds_played,omitempty"`
-	HighestBet   float64                   `json:"highest_bet,omitempty"`
+	HighestBet   Money                     `json:"highest_bet,omitempty" swaggertype:"string" example:"200.00"`
+	Currency     string                    `json:"currency,omitempty"`
 	Transactions []TransactionResponse     `json:"transactions,omitempty"`
 	CreatedAt    time.Time                 `json:"created_at,omitempty"`
 	UpdatedAt    time.Time                 `json:"updated_at,omitempty"`
+	DeletedAt    *time.Time                `json:"deleted_at,omitempty"`
 }
 
 type GameSummaryDealerResponse struct {
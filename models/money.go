@@ -0,0 +1,160 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is the ISO 4217 code of the reporting currency. Exchange
+// rates are quoted in it and casinos use it unless set otherwise.
+const DefaultCurrency = "EUR"
+
+// Money is an amount in minor units (cents), so sums and differences are
+// exact. It is stored as a two decimal numeric and travels in JSON as a
+// decimal string such as "-12.50".
+type Money int64
+
+// ParseMoney reads a decimal amount with at most two decimals, such as "12",
+// "-0.5" or "1500.25".
+func ParseMoney(s string) (Money, error) {
+	return parseMoney(s, false)
+}
+
+// parseMoney reads a decimal amount. Database aggregates like AVG can carry
+// more than two decimals; with round set those are rounded half away from
+// zero, otherwise they are an error.
+func parseMoney(s string, round bool) (Money, error) {
+	cents, err := parseFixed(s, "amount", 2, round)
+	return Money(cents), err
+}
+
+// parseFixed reads a decimal number as an integer count of 10^-places units.
+// noun names the value in errors. With round set extra decimals are rounded
+// half away from zero, otherwise they are an error.
+func parseFixed(s, noun string, places int, round bool) (int64, error) {
+	text := strings.TrimSpace(s)
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(strings.TrimPrefix(text, "-"), "+")
+
+	units, fraction, _ := strings.Cut(text, ".")
+	if units == "" && fraction == "" || strings.Trim(units+fraction, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid %s %q", noun, s)
+	}
+
+	roundUp := false
+	if len(fraction) > places {
+		if !round {
+			return 0, fmt.Errorf("%s %q has more than %d decimals", noun, s, places)
+		}
+		roundUp = fraction[places] >= '5'
+		fraction = fraction[:places]
+	}
+	fraction += strings.Repeat("0", places-len(fraction))
+
+	value, err := strconv.ParseInt(units+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s %q is out of range", noun, s)
+	}
+	if roundUp {
+		value++
+	}
+	if negative {
+		value = -value
+	}
+	return value, nil
+}
+
+// String formats the amount with two decimals, such as "-12.50".
+func (m Money) String() string {
+	return formatFixed(int64(m), 2)
+}
+
+// formatFixed formats a count of 10^-places units with all its decimals.
+func formatFixed(value int64, places int) string {
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	scale := int64(math.Pow10(places))
+	return fmt.Sprintf("%s%d.%0*d", sign, value/scale, places, value%scale)
+}
+
+// Abs returns the amount without its sign.
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+	return m
+}
+
+// Float returns the amount in major units, for ratios only.
+func (m Money) Float() float64 {
+	return float64(m) / 100
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON accepts a decimal string and, for older clients, a plain JSON
+// number. Numbers are read from their text, never through a float.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text, ok, err := decimalJSON(data, "amount")
+	if err != nil || !ok {
+		return err
+	}
+
+	parsed, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// decimalJSON returns the text of a JSON decimal string or number, and false
+// for null.
+func decimalJSON(data []byte, noun string) (string, bool, error) {
+	text := string(data)
+	if text == "null" {
+		return "", false, nil
+	}
+	if strings.HasPrefix(text, `"`) {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return "", false, err
+		}
+	} else if strings.ContainsAny(text, "eE") {
+		return "", false, fmt.Errorf("%s %s must not use exponent notation", noun, text)
+	}
+	return text, true, nil
+}
+
+// Value stores the amount as a decimal string, which Postgres reads into
+// numeric columns without loss.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+func (m *Money) Scan(value interface{}) error {
+	var err error
+	switch v := value.(type) {
+	case nil:
+		*m = 0
+	case []byte:
+		*m, err = parseMoney(string(v), true)
+	case string:
+		*m, err = parseMoney(v, true)
+	case int64:
+		*m = Money(v * 100)
+	case float64:
+		*m = Money(math.Round(v * 100))
+	default:
+		err = fmt.Errorf("cannot scan %T into Money", value)
+	}
+	return err
+}
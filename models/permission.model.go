@@ -0,0 +1,170 @@
+package models
+
+// Roles a user can hold.
+const (
+	RoleAdmin       = "admin"
+	RoleCasinoOwner = "casino_owner"
+	RoleDealer      = "dealer"
+)
+
+// IsValidRole reports whether role is one of the user roles.
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleAdmin, RoleCasinoOwner, RoleDealer:
+		return true
+	}
+	return false
+}
+
+// Permissions checked by middleware.RequirePermission. Each route requires
+// exactly one of them.
+const (
+	PermissionCasinosCreate        = "casinos:create"
+	PermissionCasinosRead          = "casinos:read"
+	PermissionCasinosUpdate        = "casinos:update"
+	PermissionCasinosDelete        = "casinos:delete"
+	PermissionCasinoGamesManage    = "casino_games:manage"
+	PermissionCasinoDealersManage  = "casino_dealers:manage"
+	PermissionTablesRead           = "tables:read"
+	PermissionTablesWrite          = "tables:write"
+	PermissionGamesRead            = "games:read"
+	PermissionGamesWrite           = "games:write"
+	PermissionPlayersRead          = "players:read"
+	PermissionPlayersWrite         = "players:write"
+	PermissionPlayersStats         = "players:stats"
+	PermissionDealersRead          = "dealers:read"
+	PermissionDealersWrite         = "dealers:write"
+	PermissionShiftsRead           = "shifts:read"
+	PermissionShiftsPlan           = "shifts:plan"
+	PermissionShiftsClock          = "shifts:clock"
+	PermissionGameSummariesCreate  = "game_summaries:create"
+	PermissionGameSummariesRead    = "game_summaries:read"
+	PermissionGameSummariesUpdate  = "game_summaries:update"
+	PermissionGameSummariesDelete  = "game_summaries:delete"
+	PermissionGameSummariesOperate = "game_summaries:operate"
+	PermissionGameSummariesVoid    = "game_summaries:void"
+	PermissionGameSummariesRecalc  = "game_summaries:recalculate"
+	PermissionRoundsRead           = "rounds:read"
+	PermissionRoundsWrite          = "rounds:write"
+	PermissionTransactionsCreate   = "transactions:create"
+	PermissionTransactionsRead     = "transactions:read"
+	PermissionTransactionsUpdate   = "transactions:update"
+	PermissionTransactionsDelete   = "transactions:delete"
+	PermissionReportsRead          = "reports:read"
+	PermissionProfileRead          = "profile:read"
+	PermissionRolesAssign          = "roles:assign"
+	PermissionPermissionsManage    = "permissions:manage"
+	PermissionAccountsUnlock       = "accounts:unlock"
+	PermissionSecurityEventsRead   = "security_events:read"
+	PermissionAuditRead            = "audit:read"
+	PermissionDeletedRecordsManage = "deleted_records:manage"
+	PermissionExchangeRatesManage  = "exchange_rates:manage"
+)
+
+// Permission is an action that can be granted to a role.
+type Permission struct {
+	Name        string `gorm:"type:varchar(100);primary_key" json:"name"`
+	Description string `gorm:"type:varchar(255);not null" json:"description"`
+}
+
+// RolePermission grants a permission to every user with the role.
+type RolePermission struct {
+	Role       string     `gorm:"type:varchar(20);primary_key" json:"role"`
+	Permission string     `gorm:"type:varchar(100);primary_key" json:"permission"`
+	Definition Permission `gorm:"foreignKey:Permission;references:Name;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+type PermissionResponse struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Roles       []string `json:"roles"`
+}
+
+// Permissions is the catalogue of permissions the migration creates.
+var Permissions = []Permission{
+	{PermissionCasinosCreate, "Create casinos"},
+	{PermissionCasinosRead, "View casinos and the games and dealers linked to them"},
+	{PermissionCasinosUpdate, "Edit casino details"},
+	{PermissionCasinosDelete, "Delete casinos"},
+	{PermissionCasinoGamesManage, "Add and remove the games a casino offers"},
+	{PermissionCasinoDealersManage, "Assign and unassign dealers to a casino"},
+	{PermissionTablesRead, "View casino tables"},
+	{PermissionTablesWrite, "Create, edit and delete casino tables"},
+	{PermissionGamesRead, "View the game catalogue"},
+	{PermissionGamesWrite, "Create, edit and delete games"},
+	{PermissionPlayersRead, "View players"},
+	{PermissionPlayersWrite, "Create, edit and delete players"},
+	{PermissionPlayersStats, "View player statistics"},
+	{PermissionDealersRead, "View dealers and their performance"},
+	{PermissionDealersWrite, "Create, edit and delete dealers"},
+	{PermissionShiftsRead, "View shifts"},
+	{PermissionShiftsPlan, "Plan and delete shifts"},
+	{PermissionShiftsClock, "Clock in and out and take breaks"},
+	{PermissionGameSummariesCreate, "Open game sessions"},
+	{PermissionGameSummariesRead, "View game sessions"},
+	{PermissionGameSummariesUpdate, "Edit game sessions"},
+	{PermissionGameSummariesDelete, "Delete game sessions"},
+	{PermissionGameSummariesOperate, "Start, pause, resume and close game sessions"},
+	{PermissionGameSummariesVoid, "Void game sessions"},
+	{PermissionGameSummariesRecalc, "Recalculate game session totals"},
+	{PermissionRoundsRead, "View rounds"},
+	{PermissionRoundsWrite, "Record and edit rounds"},
+	{PermissionTransactionsCreate, "Record transactions"},
+	{PermissionTransactionsRead, "View transactions"},
+	{PermissionTransactionsUpdate, "Correct transactions with reversal entries"},
+	{PermissionTransactionsDelete, "Delete transactions"},
+	{PermissionReportsRead, "View reports"},
+	{PermissionProfileRead, "View the current user's profile"},
+	{PermissionRolesAssign, "Assign roles and casino ownership to users"},
+	{PermissionPermissionsManage, "Grant and revoke role permissions"},
+	{PermissionAccountsUnlock, "Unlock accounts locked after failed sign-ins"},
+	{PermissionSecurityEventsRead, "View security events such as lockouts"},
+	{PermissionAuditRead, "View and verify the audit log"},
+	{PermissionDeletedRecordsManage, "List and restore deleted game summaries, transactions and players"},
+	{PermissionExchangeRatesManage, "View and set the exchange rates amounts are converted with"},
+}
+
+// DefaultRolePermissions is what each role is granted when a permission is
+// first created. Later changes are made through the admin endpoints.
+var DefaultRolePermissions = map[string][]string{
+	RoleAdmin: {
+		PermissionCasinosCreate, PermissionCasinosRead, PermissionCasinosUpdate, PermissionCasinosDelete,
+		PermissionCasinoGamesManage, PermissionCasinoDealersManage,
+		PermissionTablesRead, PermissionTablesWrite,
+		PermissionGamesRead, PermissionGamesWrite,
+		PermissionPlayersRead, PermissionPlayersWrite, PermissionPlayersStats,
+		PermissionDealersRead, PermissionDealersWrite,
+		PermissionShiftsRead, PermissionShiftsPlan, PermissionShiftsClock,
+		PermissionGameSummariesCreate, PermissionGameSummariesRead, PermissionGameSummariesUpdate,
+		PermissionGameSummariesDelete, PermissionGameSummariesOperate, PermissionGameSummariesVoid,
+		PermissionGameSummariesRecalc,
+		PermissionRoundsRead, PermissionRoundsWrite,
+		PermissionTransactionsCreate, PermissionTransactionsRead, PermissionTransactionsUpdate, PermissionTransactionsDelete,
+		PermissionReportsRead,
+		PermissionProfileRead,
+		PermissionRolesAssign, PermissionPermissionsManage,
+		PermissionAccountsUnlock, PermissionSecurityEventsRead,
+		PermissionAuditRead, PermissionDeletedRecordsManage,
+		PermissionExchangeRatesManage,
+	},
+	RoleCasinoOwner: {
+		PermissionCasinosRead, PermissionCasinosUpdate,
+		PermissionCasinoGamesManage, PermissionCasinoDealersManage,
+		PermissionTablesRead, PermissionTablesWrite,
+		PermissionGamesRead,
+		PermissionDealersRead,
+		PermissionShiftsRead, PermissionShiftsPlan,
+		PermissionReportsRead,
+	},
+	RoleDealer: {
+		PermissionCasinosRead,
+		PermissionTablesRead,
+		PermissionGamesRead,
+		PermissionPlayersRead,
+		PermissionDealersRead,
+		PermissionShiftsRead, PermissionShiftsClock,
+		PermissionGameSummariesCreate, PermissionGameSummariesUpdate, PermissionGameSummariesOperate,
+		PermissionRoundsRead, PermissionRoundsWrite,
+		PermissionTransactionsCreate, PermissionTransactionsRead,
+	},
+}
@@ -4,35 +4,89 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Player struct {
 	ID            uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id,omitempty"`
 	Nickname      string    `gorm:"type:varchar(255);not null;uniqueIndex" json:"nickname,omitempty"`
-	TotalWinnings float64   `gorm:"not null" json:"total_winnings,omitempty"`
+	TotalWinnings Money     `gorm:"type:decimal(14,2);not null;default:0" json:"total_winnings,omitempty"`
 	Rank          string    `gorm:"type:varchar(50)" json:"rank,omitempty"`
 	Status        string    `gorm:"type:varchar(50);not null" json:"status,omitempty"`
 	CreatedAt     time.Time `gorm:"not null" json:"created_at,omitempty"`
 	UpdatedAt     time.Time `gorm:"not null" json:"updated_at,omitempty"`
+	// Nicknames of deleted players stay taken so a restore cannot clash.
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
 
 type CreatePlayerRequest struct {
 	Nickname string `json:"nickname" binding:"required"`
 }
 
+// UpdatePlayerRequest leaves out TotalWinnings, which is maintained from the
+// player's transactions.
 type UpdatePlayerRequest struct {
-	Nickname      string  `json:"nickname,omitempty"`
-	TotalWinnings float64 `json:"total_winnings,omitempty"`
-	Rank          string  `json:"rank,omitempty"`
-	Status        string  `json:"status,omitempty"`
+	Nickname string `json:"nickname,omitempty"`
+	Rank     string `json:"rank,omitempty"`
+	Status   string `json:"status,omitempty"`
 }
 
 type PlayerResponse struct {
 	ID            uuid.UUID `json:"id,omitempty"`
 	Nickname      string    `json:"nickname,omitempty"`
-	TotalWinnings float64   `json:"total_winnings,omitempty"`
+	TotalWinnings Money     `json:"total_winnings,omitempty" swaggertype:"string" example:"350.00"`
+	Currency      string    `json:"currency,omitempty"`
 	Rank          string    `json:"rank,omitempty"`
 	Status        string    `json:"status,omitempty"`
 	CreatedAt     time.Time `json:"created_at,omitempty"`
 	UpdatedAt     time.Time `json:"updated_at,omitempty"`
 }
+
+type PlayerStatsResponse struct {
+	PlayerID        uuid.UUID         `json:"player_id"`
+	TotalWinnings   Money             `json:"total_winnings" swaggertype:"string" example:"350.00"`
+	Currency        string            `json:"currency"`
+	Rank            string            `json:"rank,omitempty"`
+	From            *time.Time        `json:"from,omitempty"`
+	To              *time.Time        `json:"to,omitempty"`
+	SessionsPlayed  int64             `json:"sessions_played"`
+	RoundsPlayed    int64             `json:"rounds_played"`
+	Wins            int64             `json:"wins"`
+	Losses          int64             `json:"losses"`
+	WinRate         float64           `json:"win_rate"`
+	NetResult       Money             `json:"net_result" swaggertype:"string" example:"-120.00"`
+	AverageBet      Money             `json:"average_bet" swaggertype:"string" example:"45.50"`
+	LargestBet      Money             `json:"largest_bet" swaggertype:"string" example:"200.00"`
+	FavouriteGame   *PlayerFavourite  `json:"favourite_game,omitempty"`
+	FavouriteCasino *PlayerFavourite  `json:"favourite_casino,omitempty"`
+	Games           []PlayerGameStats `json:"games"`
+}
+
+// PlayerFavourite is the game or casino where a player sat the most sessions.
+type PlayerFavourite struct {
+	ID             uuid.UUID `json:"id"`
+	Name           string    `json:"name"`
+	SessionsPlayed int64     `json:"sessions_played"`
+}
+
+type PlayerGameStats struct {
+	GameID         uuid.UUID `json:"game_id"`
+	GameName       string    `json:"game_name"`
+	SessionsPlayed int64     `json:"sessions_played"`
+	RoundsPlayed   int64     `json:"rounds_played"`
+	Wins           int64     `json:"wins"`
+	Losses         int64     `json:"losses"`
+	WinRate        float64   `json:"win_rate"`
+	NetResult      Money     `json:"net_result" swaggertype:"string" example:"-120.00"`
+	AverageBet     Money     `json:"average_bet" swaggertype:"string" example:"45.50"`
+	LargestBet     Money     `json:"largest_bet" swaggertype:"string" example:"200.00"`
+}
+
+// WinningsDiscrepancy is a player whose stored total disagrees with the sum of
+// their transactions in the reporting currency.
+type WinningsDiscrepancy struct {
+	ID            uuid.UUID
+	Nickname      string
+	TotalWinnings Money
+	LedgerTotal   Money
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CasinoRevenueReport is a casino's result for one period. Report figures are
+// seen from the house: player losses are house wins, so the gross gaming
+// revenue is the negated sum of player outcomes.
+//
+// Transactions record what each player won or lost, not what they staked, so
+// the reports cannot show handle or drop. SettledVolume is the sum of the
+// absolute outcomes instead, the money that changed hands between the house
+// and the players.
+type CasinoRevenueReport struct {
+	CasinoID           uuid.UUID `json:"casino_id"`
+	CasinoName         string    `json:"casino_name"`
+	PeriodStart        time.Time `json:"period_start"`
+	Sessions           int64     `json:"sessions"`
+	SettledVolume      Money     `json:"settled_volume" swaggertype:"string"`
+	GrossGamingRevenue Money     `json:"gross_gaming_revenue" swaggertype:"string"`
+	Currency           string    `json:"currency"`
+}
+
+// GameResultReport is the house result of a game. WinPercentage is the house
+// win as a percentage of the settled volume, see CasinoRevenueReport.
+type GameResultReport struct {
+	GameID        uuid.UUID `json:"game_id"`
+	GameName      string    `json:"game_name"`
+	Sessions      int64     `json:"sessions"`
+	SettledVolume Money     `json:"settled_volume" swaggertype:"string"`
+	HouseWin      Money     `json:"house_win" swaggertype:"string"`
+	WinPercentage float64   `json:"win_percentage"`
+	Currency      string    `json:"currency"`
+}
+
+// DealerTableReport is the house result of the sessions a dealer ran.
+type DealerTableReport struct {
+	DealerID      uuid.UUID `json:"dealer_id"`
+	DealerCode    string    `json:"dealer_code"`
+	Sessions      int64     `json:"sessions"`
+	SettledVolume Money     `json:"settled_volume" swaggertype:"string"`
+	HouseWin      Money     `json:"house_win" swaggertype:"string"`
+	Currency      string    `json:"currency"`
+}
+
+// TableReport is the house result of the sessions run at a physical table.
+type TableReport struct {
+	TableID       uuid.UUID `json:"table_id"`
+	TableNumber   string    `json:"table_number"`
+	CasinoID      uuid.UUID `json:"casino_id"`
+	CasinoName    string    `json:"casino_name"`
+	Sessions      int64     `json:"sessions"`
+	SettledVolume Money     `json:"settled_volume" swaggertype:"string"`
+	HouseWin      Money     `json:"house_win" swaggertype:"string"`
+	Currency      string    `json:"currency"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Round struct {
+	ID            uuid.UUID       `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id,omitempty"`
+	GameSummaryID uuid.UUID       `gorm:"type:uuid;not null;uniqueIndex:idx_rounds_game_summary_round" json:"game_summary_id,omitempty"`
+	RoundNumber   int             `gorm:"not null;uniqueIndex:idx_rounds_game_summary_round" json:"round_number,omitempty"`
+	DealerID      uuid.UUID       `gorm:"type:uuid;not null" json:"dealer_id,omitempty"`
+	StartTime     time.Time       `gorm:"not null" json:"start_time,omitempty"`
+	EndTime       *time.Time      `json:"end_time,omitempty"`
+	Outcome       json.RawMessage `gorm:"type:jsonb" json:"outcome,omitempty"`
+	Transactions  []Transaction   `gorm:"foreignKey:RoundID" json:"transactions,omitempty"`
+	CreatedAt     time.Time       `gorm:"not null" json:"created_at,omitempty"`
+	UpdatedAt     time.Time       `gorm:"not null" json:"updated_at,omitempty"`
+}
+
+// CreateRoundRequest records a hand or spin. The round number defaults to the
+// next one in the session and the dealer defaults to the session's dealer.
+type CreateRoundRequest struct {
+	RoundNumber int             `json:"round_number,omitempty"`
+	DealerID    string          `json:"dealer_id,omitempty"`
+	StartTime   time.Time       `json:"start_time,omitempty"`
+	EndTime     *time.Time      `json:"end_time,omitempty"`
+	Outcome     json.RawMessage `json:"outcome,omitempty"`
+}
+
+type UpdateRoundRequest struct {
+	EndTime *time.Time      `json:"end_time,omitempty"`
+	Outcome json.RawMessage `json:"outcome,omitempty"`
+}
+
+type RoundResponse struct {
+	ID            uuid.UUID             `json:"id,omitempty"`
+	GameSummaryID uuid.UUID             `json:"game_summary_id,omitempty"`
+	RoundNumber   int                   `json:"round_number,omitempty"`
+	DealerID      uuid.UUID             `json:"dealer_id,omitempty"`
+	StartTime     time.Time             `json:"start_time,omitempty"`
+	EndTime       *time.Time            `json:"end_time,omitempty"`
+	Outcome       json.RawMessage       `json:"outcome,omitempty"`
+	Transactions  []TransactionResponse `json:"transactions,omitempty"`
+	CreatedAt     time.Time             `json:"created_at,omitempty"`
+	UpdatedAt     time.Time             `json:"updated_at,omitempty"`
+}
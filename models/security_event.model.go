@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of security event.
+const (
+	SecurityEventAccountLocked   = "account_locked"
+	SecurityEventIPBlocked       = "ip_blocked"
+	SecurityEventAccountUnlocked = "account_unlocked"
+)
+
+// SecurityEvent records a lockout or an admin lifting one. UserID is empty
+// when the locked email address does not belong to a user.
+type SecurityEvent struct {
+	ID          uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id,omitempty"`
+	Type        string     `gorm:"type:varchar(30);not null;index" json:"type"`
+	UserID      *uuid.UUID `gorm:"type:uuid;index" json:"user_id,omitempty"`
+	Email       string     `gorm:"type:varchar(255)" json:"email,omitempty"`
+	ClientIP    string     `gorm:"type:varchar(45)" json:"client_ip,omitempty"`
+	ActorID     *uuid.UUID `gorm:"type:uuid" json:"actor_id,omitempty"`
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+	CreatedAt   time.Time  `gorm:"not null;index" json:"created_at"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is one login of a user. Every refresh token issued for the login
+// belongs to the same session; CurrentTokenID is the only one that may still
+// be used. Presenting an older token revokes the whole session.
+type Session struct {
+	ID             uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id,omitempty"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;index" json:"-"`
+	User           User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+	CurrentTokenID uuid.UUID  `gorm:"type:uuid;not null" json:"-"`
+	UserAgent      string     `gorm:"type:varchar(255)" json:"user_agent,omitempty"`
+	ClientIP       string     `gorm:"type:varchar(45)" json:"client_ip,omitempty"`
+	ExpiresAt      time.Time  `gorm:"not null" json:"expires_at,omitempty"`
+	LastUsedAt     time.Time  `gorm:"not null" json:"last_used_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `gorm:"not null" json:"created_at,omitempty"`
+	UpdatedAt      time.Time  `gorm:"not null" json:"updated_at,omitempty"`
+}
+
+// IsActive reports whether the session can still be refreshed at now.
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+type SessionResponse struct {
+	ID         uuid.UUID `json:"id,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	ClientIP   string    `json:"client_ip,omitempty"`
+	Current    bool      `json:"current"`
+	ExpiresAt  time.Time `json:"expires_at,omitempty"`
+	LastUsedAt time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time `json:"created_at,omitempty"`
+}
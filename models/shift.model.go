@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Shift states. A planned shift becomes active when the dealer clocks in and
+// completed when they clock out. Clocking in without a plan opens an active
+// shift straight away.
+const (
+	ShiftStatusPlanned   = "planned"
+	ShiftStatusActive    = "active"
+	ShiftStatusCompleted = "completed"
+)
+
+type Shift struct {
+	ID           uuid.UUID    `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id,omitempty"`
+	DealerID     uuid.UUID    `gorm:"type:uuid;not null" json:"dealer_id,omitempty"`
+	Dealer       Dealer       `gorm:"foreignKey:DealerID;constraint:OnDelete:CASCADE;" json:"-"`
+	CasinoID     uuid.UUID    `gorm:"type:uuid;not null" json:"casino_id,omitempty"`
+	Casino       Casino       `gorm:"foreignKey:CasinoID;constraint:OnDelete:CASCADE;" json:"-"`
+	PlannedStart *time.Time   `json:"planned_start,omitempty"`
+	PlannedEnd   *time.Time   `json:"planned_end,omitempty"`
+	ClockInAt    *time.Time   `json:"clock_in_at,omitempty"`
+	ClockOutAt   *time.Time   `json:"clock_out_at,omitempty"`
+	Status       string       `gorm:"type:varchar(20);not null" json:"status,omitempty"`
+	Breaks       []ShiftBreak `gorm:"foreignKey:ShiftID;constraint:OnDelete:CASCADE;" json:"breaks,omitempty"`
+	CreatedAt    time.Time    `gorm:"not null" json:"created_at,omitempty"`
+	UpdatedAt    time.Time    `gorm:"not null" json:"updated_at,omitempty"`
+}
+
+type ShiftBreak struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id,omitempty"`
+	ShiftID   uuid.UUID  `gorm:"type:uuid;not null" json:"shift_id,omitempty"`
+	StartTime time.Time  `gorm:"not null" json:"start_time,omitempty"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+	CreatedAt time.Time  `gorm:"not null" json:"created_at,omitempty"`
+	UpdatedAt time.Time  `gorm:"not null" json:"updated_at,omitempty"`
+}
+
+type CreateShiftRequest struct {
+	DealerID     string    `json:"dealer_id" binding:"required"`
+	CasinoID     string    `json:"casino_id" binding:"required"`
+	PlannedStart time.Time `json:"planned_start" binding:"required"`
+	PlannedEnd   time.Time `json:"planned_end" binding:"required"`
+}
+
+type ClockInRequest struct {
+	CasinoID string `json:"casino_id" binding:"required"`
+}
+
+type ShiftBreakResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	StartTime time.Time  `json:"start_time"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+}
+
+type ShiftResponse struct {
+	ID           uuid.UUID            `json:"id"`
+	DealerID     uuid.UUID            `json:"dealer_id"`
+	CasinoID     uuid.UUID            `json:"casino_id"`
+	PlannedStart *time.Time           `json:"planned_start,omitempty"`
+	PlannedEnd   *time.Time           `json:"planned_end,omitempty"`
+	ClockInAt    *time.Time           `json:"clock_in_at,omitempty"`
+	ClockOutAt   *time.Time           `json:"clock_out_at,omitempty"`
+	Status       string               `json:"status"`
+	OnBreak      bool                 `json:"on_break"`
+	Breaks       []ShiftBreakResponse `json:"breaks"`
+	CreatedAt    time.Time            `json:"created_at"`
+	UpdatedAt    time.Time            `json:"updated_at"`
+}
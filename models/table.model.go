@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Table states. Sessions can only be opened on an open table.
+const (
+	TableStatusOpen        = "open"
+	TableStatusClosed      = "closed"
+	TableStatusMaintenance = "maintenance"
+)
+
+// IsValidTableStatus reports whether status is one of the table states.
+func IsValidTableStatus(status string) bool {
+	switch status {
+	case TableStatusOpen, TableStatusClosed, TableStatusMaintenance:
+		return true
+	}
+	return false
+}
+
+// Table is a physical gaming table on a casino floor. MinBet and MaxBet
+// override the game's limits when set.
+type Table struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id,omitempty"`
+	CasinoID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_tables_casino_table_number" json:"casino_id,omitempty"`
+	Casino      Casino    `gorm:"foreignKey:CasinoID;constraint:OnDelete:CASCADE;" json:"-"`
+	TableNumber string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_tables_casino_table_number" json:"table_number,omitempty"`
+	GameID      uuid.UUID `gorm:"type:uuid;not null" json:"game_id,omitempty"`
+	Game        Game      `gorm:"foreignKey:GameID" json:"-"`
+	MinBet      *Money    `gorm:"type:decimal(14,2)" json:"min_bet,omitempty"`
+	MaxBet      *Money    `gorm:"type:decimal(14,2)" json:"max_bet,omitempty"`
+	Status      string    `gorm:"type:varchar(20);not null" json:"status,omitempty"`
+	CreatedAt   time.Time `gorm:"not null" json:"created_at,omitempty"`
+	UpdatedAt   time.Time `gorm:"not null" json:"updated_at,omitempty"`
+}
+
+type CreateTableRequest struct {
+	TableNumber string `json:"table_number" binding:"required"`
+	GameID      string `json:"game_id" binding:"required"`
+	MinBet      *Money `json:"min_bet,omitempty" swaggertype:"string" example:"25.00"`
+	MaxBet      *Money `json:"max_bet,omitempty" swaggertype:"string" example:"500.00"`
+	Status      string `json:"status,omitempty"`
+}
+
+// UpdateTableRequest changes only the fields that are set. ClearMinBet and
+// ClearMaxBet drop an override so the game's limit applies again.
+type UpdateTableRequest struct {
+	TableNumber string `json:"table_number,omitempty"`
+	GameID      string `json:"game_id,omitempty"`
+	MinBet      *Money `json:"min_bet,omitempty" swaggertype:"string" example:"25.00"`
+	MaxBet      *Money `json:"max_bet,omitempty" swaggertype:"string" example:"500.00"`
+	ClearMinBet bool   `json:"clear_min_bet,omitempty"`
+	ClearMaxBet bool   `json:"clear_max_bet,omitempty"`
+	Status      string `json:"status,omitempty"`
+}
+
+type TableResponse struct {
+	ID          uuid.UUID    `json:"id,omitempty"`
+	CasinoID    uuid.UUID    `json:"casino_id,omitempty"`
+	TableNumber string       `json:"table_number,omitempty"`
+	Game        GameResponse `json:"game,omitempty"`
+	MinBet      *Money       `json:"min_bet,omitempty" swaggertype:"string" example:"25.00"`
+	MaxBet      *Money       `json:"max_bet,omitempty" swaggertype:"string" example:"500.00"`
+	Currency    string       `json:"currency,omitempty"`
+	Status      string       `json:"status,omitempty"`
+	CreatedAt   time.Time    `json:"created_at,omitempty"`
+	UpdatedAt   time.Time    `json:"updated_at,omitempty"`
+}
@@ -4,39 +4,71 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Transaction struct {
-	ID            uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id,omitempty"`
-	GameSummaryID uuid.UUID `gorm:"type:uuid;not null" json:"id,omitempty"`
-	PlayerID      uuid.UUID `gorm:"type:uuid;not null" json:"id,omitempty"`
-	Player        Player    `gorm:"foreignKey:PlayerID" json:"player,omitempty"`
-	Amount        float64   `gorm:"type:decimal(10,2);not null" json:"amount,omitempty"`
-	Type          string    `gorm:"type:varchar(50);not null" json:"-"`
-	Outcome       string    `gorm:"type:varchar(10);not null;default:'loss'" json:"outcome,omitempty"`
-	CreatedAt     time.Time `gorm:"not null" json:"created_at,omitempty"`
-	UpdatedAt     time.Time `gorm:"not null" json:"updated_at,omitempty"`
+	ID            uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id,omitempty"`
+	GameSummaryID uuid.UUID  `gorm:"type:uuid;not null" json:"game_summary_id,omitempty"`
+	PlayerID      uuid.UUID  `gorm:"type:uuid;not null" json:"player_id,omitempty"`
+	Player        Player     `gorm:"foreignKey:PlayerID" json:"player,omitempty"`
+	RoundID       *uuid.UUID `gorm:"type:uuid" json:"round_id,omitempty"`
+	Amount        Money      `gorm:"type:decimal(14,2);not null" json:"amount,omitempty"`
+	Currency      string     `gorm:"type:varchar(3);not null;default:'EUR'" json:"currency,omitempty"`
+	Type          string     `gorm:"type:varchar(50);not null" json:"-"`
+	Outcome       string     `gorm:"type:varchar(10);not null;default:'loss'" json:"outcome,omitempty"`
+	// Transactions are never edited. A correction reverses the original with an
+	// entry for the negated amount and books the corrected figure as a new entry;
+	// both carry the reason. The unique index allows one reversal per transaction.
+	ReversesID *uuid.UUID     `gorm:"type:uuid;uniqueIndex" json:"reverses_id,omitempty"`
+	CorrectsID *uuid.UUID     `gorm:"type:uuid;index" json:"corrects_id,omitempty"`
+	Reason     string         `gorm:"type:text" json:"reason,omitempty"`
+	CreatedAt  time.Time      `gorm:"not null" json:"created_at,omitempty"`
+	UpdatedAt  time.Time      `gorm:"not null" json:"updated_at,omitempty"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
 
 type CreateTransactionRequest struct {
-	GameSummaryID string  `json:"game_summary_id" binding:"required"`
-	PlayerID      string  `json:"player_id" binding:"required"`
-	Amount        float64 `json:"amount" binding:"required"`
+	GameSummaryID string `json:"game_summary_id" binding:"required"`
+	PlayerID      string `json:"player_id" binding:"required"`
+	RoundID       string `json:"round_id,omitempty"`
+	Amount        Money  `json:"amount" binding:"required" swaggertype:"string" example:"-25.00"`
 	//Type          string  `json:"type" binding:"required"`
 	Outcome string `json:"outcome" binding:"required,oneof=win loss"`
 }
 
-type UpdateTransactionRequest struct {
-	Amount float64 `json:"amount,omitempty"`
-	//Type    string  `json:"type,omitempty"`
-	Outcome string `json:"outcome,omitempty"`
+// Entry types of the two transactions a correction books.
+const (
+	TransactionTypeReversal   = "reversal"
+	TransactionTypeCorrection = "correction"
+)
+
+// CorrectTransactionRequest holds the figures the transaction should have had.
+type CorrectTransactionRequest struct {
+	Amount  Money  `json:"amount" binding:"required" swaggertype:"string" example:"150.00"`
+	Outcome string `json:"outcome" binding:"required,oneof=win loss"`
+	Reason  string `json:"reason" binding:"required"`
 }
 
 type TransactionResponse struct {
-	ID        uuid.UUID      `json:"id"`
-	Player    PlayerResponse `json:"player"`
-	Amount    float64        `json:"amount"`
-	Outcome   string         `json:"outcome"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
+	ID         uuid.UUID      `json:"id"`
+	Player     PlayerResponse `json:"player"`
+	RoundID    *uuid.UUID     `json:"round_id,omitempty"`
+	Amount     Money          `json:"amount" swaggertype:"string" example:"-25.00"`
+	Currency   string         `json:"currency"`
+	Outcome    string         `json:"outcome"`
+	ReversesID *uuid.UUID     `json:"reverses_id,omitempty"`
+	CorrectsID *uuid.UUID     `json:"corrects_id,omitempty"`
+	Reason     string         `json:"reason,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	// CorrectionChain lists every entry descending from the same original
+	// transaction, oldest first. Only set on single transaction lookups.
+	CorrectionChain []TransactionResponse `json:"correction_chain,omitempty"`
+}
+
+// CorrectionResponse is the pair of entries booked by a correction.
+type CorrectionResponse struct {
+	Reversal  TransactionResponse `json:"reversal"`
+	Corrected TransactionResponse `json:"corrected"`
 }
@@ -18,6 +18,12 @@ type User struct {
 	Verified  bool      `gorm:"not null" json:"verified,omitempty"`
 	CreatedAt time.Time `gorm:"not null" json:"created_at,omitempty"`
 	UpdatedAt time.Time `gorm:"not null" json:"updated_at,omitempty"`
+
+	// TOTPSecret is set when enrolment starts; 2FA is only enforced once
+	// TwoFactorEnabled is true. TOTPLastStep stops a code being used twice.
+	TOTPSecret       string `gorm:"type:varchar(64)" json:"-"`
+	TOTPLastStep     int64  `gorm:"not null;default:0" json:"-"`
+	TwoFactorEnabled bool   `gorm:"not null;default:false" json:"two_factor_enabled"`
 }
 
 type SignUpInput struct {
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// What a user token can be exchanged for. A two-factor challenge token is the
+// ID of the challenge JWT, so each challenge completes one sign-in.
+const (
+	TokenPurposeEmailVerification  = "email_verification"
+	TokenPurposePasswordReset      = "password_reset"
+	TokenPurposeTwoFactorChallenge = "2fa_challenge"
+)
+
+// UserToken is a single-use secret mailed to a user or carried in a two-factor
+// challenge. Only its SHA-256 hash is stored, so a database leak does not
+// expose usable tokens.
+type UserToken struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id,omitempty"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"-"`
+	User      User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+	Purpose   string     `gorm:"type:varchar(30);not null" json:"purpose,omitempty"`
+	TokenHash string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at,omitempty"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `gorm:"not null" json:"created_at,omitempty"`
+}
+
+type VerifyEmailInput struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type EmailInput struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordInput struct {
+	Token           string `json:"token" binding:"required"`
+	Password        string `json:"password" binding:"required,min=8"`
+	PasswordConfirm string `json:"passwordConfirm" binding:"required"`
+}
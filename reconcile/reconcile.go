@@ -0,0 +1,51 @@
+// reconcile/reconcile.go
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/suidevv/tableye-api/controllers"
+	"github.com/suidevv/tableye-api/initializers"
+)
+
+func init() {
+	config, err := initializers.LoadConfig(".")
+	if err != nil {
+		log.Fatal("🚀 Could not load environment variables: ", err)
+	}
+	initializers.ConnectDB(&config)
+}
+
+func main() {
+	fix := len(os.Args) > 1 && os.Args[1] == "--fix"
+
+	discrepancies, err := controllers.FindWinningsDiscrepancies(initializers.DB)
+	if err != nil {
+		log.Fatal("Failed to compare player winnings: ", err)
+	}
+
+	if len(discrepancies) == 0 {
+		fmt.Println("👍 All player winnings match their transactions")
+		return
+	}
+
+	for _, d := range discrepancies {
+		fmt.Printf("%s (%s): stored %s, transactions %s, difference %s\n",
+			d.Nickname, d.ID, d.TotalWinnings, d.LedgerTotal, d.TotalWinnings-d.LedgerTotal)
+	}
+
+	if !fix {
+		fmt.Printf("Found %d players with mismatched winnings, run with --fix to correct them\n", len(discrepancies))
+		return
+	}
+
+	corrected, err := controllers.ReconcilePlayerWinnings(initializers.DB)
+	if err != nil {
+		log.Fatal("Failed to fix player winnings: ", err)
+	}
+
+	fmt.Printf("👍 Corrected winnings of %d players\n", corrected)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/suidevv/tableye-api/controllers"
 	"github.com/suidevv/tableye-api/middleware"
+	"github.com/suidevv/tableye-api/models"
 )
 
 type AdminRouteController struct {
@@ -16,7 +17,17 @@ func NewRouteAdminController(adminController controllers.AdminController) AdminR
 
 func (rc *AdminRouteController) AdminRoute(rg *gin.RouterGroup) {
 	router := rg.Group("admin")
-	router.Use(middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"))
+	router.Use(middleware.DeserializeUser())
 
-	router.POST("/assign-admin", rc.adminController.AssignAdminRole)
+	router.POST("/assign-admin", middleware.RequirePermission(models.PermissionRolesAssign), rc.adminController.AssignAdminRole)
+	router.POST("/game-summaries/recalculate-totals", middleware.RequirePermission(models.PermissionGameSummariesRecalc), rc.adminController.RecalculateGameSummaryTotals)
+	router.GET("/casinos/:casinoId/owners", middleware.RequirePermission(models.PermissionRolesAssign), rc.adminController.FindCasinoOwners)
+	router.POST("/casinos/:casinoId/owners/:userId", middleware.RequirePermission(models.PermissionRolesAssign), rc.adminController.GrantCasinoOwnership)
+	router.DELETE("/casinos/:casinoId/owners/:userId", middleware.RequirePermission(models.PermissionRolesAssign), rc.adminController.RevokeCasinoOwnership)
+	router.POST("/users/:userId/unlock", middleware.RequirePermission(models.PermissionAccountsUnlock), rc.adminController.UnlockAccount)
+	router.GET("/security-events", middleware.RequirePermission(models.PermissionSecurityEventsRead), rc.adminController.FindSecurityEvents)
+	router.GET("/audit", middleware.RequirePermission(models.PermissionAuditRead), rc.adminController.FindAuditEvents)
+	router.GET("/audit/verify", middleware.RequirePermission(models.PermissionAuditRead), rc.adminController.VerifyAuditChain)
+	router.GET("/exchange-rates", middleware.RequirePermission(models.PermissionExchangeRatesManage), rc.adminController.FindExchangeRates)
+	router.PUT("/exchange-rates", middleware.RequirePermission(models.PermissionExchangeRatesManage), rc.adminController.SetExchangeRate)
 }
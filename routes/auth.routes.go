@@ -19,6 +19,17 @@ func (rc *AuthRouteController) AuthRoute(rg *gin.RouterGroup) {
 
 	router.POST("/register", rc.authController.SignUpUser)
 	router.POST("/login", rc.authController.SignInUser)
-	router.GET("/refresh", rc.authController.RefreshAccessToken)
-	router.GET("/logout", middleware.DeserializeUser(), rc.authController.LogoutUser)
+	router.POST("/verify-email", rc.authController.VerifyEmail)
+	router.POST("/resend-verification", rc.authController.ResendVerification)
+	router.POST("/forgot-password", rc.authController.ForgotPassword)
+	router.POST("/reset-password", rc.authController.ResetPassword)
+	router.POST("/refresh", rc.authController.RefreshAccessToken)
+	router.GET("/logout", middleware.DeserializeUserForEnrolment(), rc.authController.LogoutUser)
+	router.GET("/sessions", middleware.DeserializeUser(), rc.authController.FindSessions)
+	router.DELETE("/sessions/:sessionId", middleware.DeserializeUser(), rc.authController.RevokeSession)
+	router.POST("/2fa/verify", rc.authController.VerifyTwoFactor)
+	router.POST("/2fa/setup", middleware.DeserializeUserForEnrolment(), rc.authController.SetupTwoFactor)
+	router.POST("/2fa/enable", middleware.DeserializeUserForEnrolment(), rc.authController.EnableTwoFactor)
+	router.POST("/2fa/disable", middleware.DeserializeUser(), rc.authController.DisableTwoFactor)
+	router.POST("/2fa/recovery-codes", middleware.DeserializeUser(), rc.authController.RegenerateRecoveryCodes)
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/suidevv/tableye-api/controllers"
 	"github.com/suidevv/tableye-api/middleware"
+	"github.com/suidevv/tableye-api/models"
 )
 
 type CasinoRouteController struct {
@@ -17,9 +18,15 @@ func NewRouteCasinoController(casinoController controllers.CasinoController) Cas
 func (cc *CasinoRouteController) CasinoRoute(rg *gin.RouterGroup) {
 	router := rg.Group("casinos")
 
-	router.POST("/", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"), cc.casinoController.CreateCasino)
-	router.GET("/", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin", "dealer"), cc.casinoController.FindCasinos)
-	router.GET("/:casinoId", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin", "dealer"), cc.casinoController.FindCasinoById)
-	router.PUT("/:casinoId", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"), cc.casinoController.UpdateCasino)
-	router.DELETE("/:casinoId", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"), cc.casinoController.DeleteCasino)
+	router.POST("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionCasinosCreate), middleware.ScopeCasinos(), cc.casinoController.CreateCasino)
+	router.GET("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionCasinosRead), middleware.ScopeCasinos(), cc.casinoController.FindCasinos)
+	router.GET("/:casinoId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionCasinosRead), middleware.ScopeCasinos(), cc.casinoController.FindCasinoById)
+	router.PUT("/:casinoId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionCasinosUpdate), middleware.ScopeCasinos(), cc.casinoController.UpdateCasino)
+	router.DELETE("/:casinoId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionCasinosDelete), middleware.ScopeCasinos(), cc.casinoController.DeleteCasino)
+	router.GET("/:casinoId/games", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionCasinosRead), middleware.ScopeCasinos(), cc.casinoController.FindCasinoGames)
+	router.POST("/:casinoId/games/:gameId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionCasinoGamesManage), middleware.ScopeCasinos(), cc.casinoController.AddCasinoGame)
+	router.DELETE("/:casinoId/games/:gameId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionCasinoGamesManage), middleware.ScopeCasinos(), cc.casinoController.RemoveCasinoGame)
+	router.GET("/:casinoId/dealers", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionCasinosRead), middleware.ScopeCasinos(), cc.casinoController.FindCasinoDealers)
+	router.POST("/:casinoId/dealers/:dealerId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionCasinoDealersManage), middleware.ScopeCasinos(), cc.casinoController.AddCasinoDealer)
+	router.DELETE("/:casinoId/dealers/:dealerId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionCasinoDealersManage), middleware.ScopeCasinos(), cc.casinoController.RemoveCasinoDealer)
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/suidevv/tableye-api/controllers"
 	"github.com/suidevv/tableye-api/middleware"
+	"github.com/suidevv/tableye-api/models"
 )
 
 type DealerRouteController struct {
@@ -17,9 +18,11 @@ func NewRouteDealerController(dealerController controllers.DealerController) Dea
 func (dc *DealerRouteController) DealerRoute(rg *gin.RouterGroup) {
 	router := rg.Group("dealers")
 
-	router.POST("/", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"), dc.dealerController.CreateDealer)
-	router.GET("/", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin", "dealer"), dc.dealerController.FindDealers)
-	router.GET("/:dealerId", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin", "dealer"), dc.dealerController.FindDealerById)
-	router.PUT("/:dealerId", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"), dc.dealerController.UpdateDealer)
-	router.DELETE("/:dealerId", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"), dc.dealerController.DeleteDealer)
+	router.POST("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionDealersWrite), middleware.ScopeCasinos(), dc.dealerController.CreateDealer)
+	router.GET("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionDealersRead), middleware.ScopeCasinos(), dc.dealerController.FindDealers)
+	router.GET("/:dealerId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionDealersRead), middleware.ScopeCasinos(), dc.dealerController.FindDealerById)
+	router.PUT("/:dealerId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionDealersWrite), middleware.ScopeCasinos(), dc.dealerController.UpdateDealer)
+	router.DELETE("/:dealerId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionDealersWrite), middleware.ScopeCasinos(), dc.dealerController.DeleteDealer)
+	router.GET("/:dealerId/performance", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionDealersRead), middleware.ScopeCasinos(), dc.dealerController.FindDealerPerformance)
+	router.GET("/:dealerId/casinos", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionDealersRead), middleware.ScopeCasinos(), dc.dealerController.FindDealerCasinos)
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/suidevv/tableye-api/controllers"
 	"github.com/suidevv/tableye-api/middleware"
+	"github.com/suidevv/tableye-api/models"
 )
 
 type GameRouteController struct {
@@ -17,9 +18,10 @@ func NewRouteGameController(gameController controllers.GameController) GameRoute
 func (gc *GameRouteController) GameRoute(rg *gin.RouterGroup) {
 	router := rg.Group("games")
 
-	router.POST("/", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"), gc.gameController.CreateGame)
-	router.GET("/", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin", "dealer"), gc.gameController.FindGames)
-	router.GET("/:gameId", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin", "dealer"), gc.gameController.FindGameById)
-	router.PUT("/:gameId", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"), gc.gameController.UpdateGame)
-	router.DELETE("/:gameId", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"), gc.gameController.DeleteGame)
+	router.POST("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionGamesWrite), gc.gameController.CreateGame)
+	router.GET("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionGamesRead), gc.gameController.FindGames)
+	router.GET("/:gameId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionGamesRead), gc.gameController.FindGameById)
+	router.PUT("/:gameId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionGamesWrite), gc.gameController.UpdateGame)
+	router.DELETE("/:gameId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionGamesWrite), gc.gameController.DeleteGame)
+	router.GET("/:gameId/casinos", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionGamesRead), middleware.ScopeCasinos(), gc.gameController.FindGameCasinos)
 }
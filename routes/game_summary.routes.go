@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/suidevv/tableye-api/controllers"
 	"github.com/suidevv/tableye-api/middleware"
+	"github.com/suidevv/tableye-api/models"
 )
 
 type GameSummaryRouteController struct {
@@ -17,9 +18,19 @@ func NewRouteGameSummaryController(gameSummaryController controllers.GameSummary
 func (gsc *GameSummaryRouteController) GameSummaryRoute(rg *gin.RouterGroup) {
 	router := rg.Group("game-summaries")
 
-	router.POST("/", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin", "dealer"), gsc.gameSummaryController.CreateGameSummary)
-	router.GET("/", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"), gsc.gameSummaryController.FindGameSummaries)
-	router.GET("/:gameSummaryId", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"), gsc.gameSummaryController.FindGameSummaryById)
-	router.PUT("/:gameSummaryId", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin", "dealer"), gsc.gameSummaryController.UpdateGameSummary)
-	router.DELETE("/:gameSummaryId", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"), gsc.gameSummaryController.DeleteGameSummary)
+	router.POST("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionGameSummariesCreate), middleware.ScopeCasinos(), gsc.gameSummaryController.CreateGameSummary)
+	router.GET("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionGameSummariesRead), middleware.ScopeCasinos(), gsc.gameSummaryController.FindGameSummaries)
+	router.GET("/:gameSummaryId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionGameSummariesRead), middleware.ScopeCasinos(), gsc.gameSummaryController.FindGameSummaryById)
+	router.PUT("/:gameSummaryId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionGameSummariesUpdate), middleware.ScopeCasinos(), gsc.gameSummaryController.UpdateGameSummary)
+	router.DELETE("/:gameSummaryId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionGameSummariesDelete), middleware.ScopeCasinos(), gsc.gameSummaryController.DeleteGameSummary)
+	router.POST("/:gameSummaryId/start", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionGameSummariesOperate), middleware.ScopeCasinos(), gsc.gameSummaryController.StartGameSummary)
+	router.POST("/:gameSummaryId/pause", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionGameSummariesOperate), middleware.ScopeCasinos(), gsc.gameSummaryController.PauseGameSummary)
+	router.POST("/:gameSummaryId/resume", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionGameSummariesOperate), middleware.ScopeCasinos(), gsc.gameSummaryController.ResumeGameSummary)
+	router.POST("/:gameSummaryId/close", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionGameSummariesOperate), middleware.ScopeCasinos(), gsc.gameSummaryController.CloseGameSummary)
+	router.POST("/:gameSummaryId/void", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionGameSummariesVoid), middleware.ScopeCasinos(), gsc.gameSummaryController.VoidGameSummary)
+
+	admin := rg.Group("admin/deleted/game-summaries")
+
+	admin.GET("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionDeletedRecordsManage), middleware.ScopeCasinos(), gsc.gameSummaryController.FindDeletedGameSummaries)
+	admin.POST("/:gameSummaryId/restore", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionDeletedRecordsManage), middleware.ScopeCasinos(), gsc.gameSummaryController.RestoreGameSummary)
 }
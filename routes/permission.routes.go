@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/suidevv/tableye-api/controllers"
+	"github.com/suidevv/tableye-api/middleware"
+	"github.com/suidevv/tableye-api/models"
+)
+
+type PermissionRouteController struct {
+	permissionController controllers.PermissionController
+}
+
+func NewRoutePermissionController(permissionController controllers.PermissionController) PermissionRouteController {
+	return PermissionRouteController{permissionController}
+}
+
+func (pc *PermissionRouteController) PermissionRoute(rg *gin.RouterGroup) {
+	router := rg.Group("admin")
+	router.Use(middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionPermissionsManage))
+
+	router.GET("/permissions", pc.permissionController.FindPermissions)
+	router.GET("/roles/:role/permissions", pc.permissionController.FindRolePermissions)
+	router.POST("/roles/:role/permissions/:permission", pc.permissionController.GrantRolePermission)
+	router.DELETE("/roles/:role/permissions/:permission", pc.permissionController.RevokeRolePermission)
+}
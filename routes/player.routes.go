@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/suidevv/tableye-api/controllers"
 	"github.com/suidevv/tableye-api/middleware"
+	"github.com/suidevv/tableye-api/models"
 )
 
 type PlayerRouteController struct {
@@ -17,10 +18,15 @@ func NewRoutePlayerController(playerController controllers.PlayerController) Pla
 func (pc *PlayerRouteController) PlayerRoute(rg *gin.RouterGroup) {
 	router := rg.Group("players")
 
-	router.POST("/", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"), pc.playerController.CreatePlayer)
-	router.GET("/", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin", "dealer"), pc.playerController.FindPlayers)
-	router.GET("/:playerId", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin", "dealer"), pc.playerController.FindPlayerById)
-	router.PUT("/:playerId", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"), pc.playerController.UpdatePlayer)
-	router.DELETE("/:playerId", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"), pc.playerController.DeletePlayer)
-	router.GET("/:playerId/stats", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"), pc.playerController.FindPlayerStats)
+	router.POST("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionPlayersWrite), pc.playerController.CreatePlayer)
+	router.GET("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionPlayersRead), pc.playerController.FindPlayers)
+	router.GET("/:playerId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionPlayersRead), pc.playerController.FindPlayerById)
+	router.PUT("/:playerId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionPlayersWrite), pc.playerController.UpdatePlayer)
+	router.DELETE("/:playerId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionPlayersWrite), pc.playerController.DeletePlayer)
+	router.GET("/:playerId/stats", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionPlayersStats), pc.playerController.FindPlayerStats)
+
+	admin := rg.Group("admin/deleted/players")
+
+	admin.GET("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionDeletedRecordsManage), pc.playerController.FindDeletedPlayers)
+	admin.POST("/:playerId/restore", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionDeletedRecordsManage), pc.playerController.RestorePlayer)
 }
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/suidevv/tableye-api/controllers"
+	"github.com/suidevv/tableye-api/middleware"
+	"github.com/suidevv/tableye-api/models"
+)
+
+type ReportRouteController struct {
+	reportController controllers.ReportController
+}
+
+func NewRouteReportController(reportController controllers.ReportController) ReportRouteController {
+	return ReportRouteController{reportController}
+}
+
+func (rc *ReportRouteController) ReportRoute(rg *gin.RouterGroup) {
+	router := rg.Group("reports")
+	router.Use(middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionReportsRead), middleware.ScopeCasinos())
+
+	router.GET("/revenue", rc.reportController.CasinoRevenue)
+	router.GET("/games", rc.reportController.GameResults)
+	router.GET("/dealers", rc.reportController.DealerTables)
+	router.GET("/tables", rc.reportController.TableResults)
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/suidevv/tableye-api/controllers"
+	"github.com/suidevv/tableye-api/middleware"
+	"github.com/suidevv/tableye-api/models"
+)
+
+type RoundRouteController struct {
+	roundController controllers.RoundController
+}
+
+func NewRouteRoundController(roundController controllers.RoundController) RoundRouteController {
+	return RoundRouteController{roundController}
+}
+
+func (rc *RoundRouteController) RoundRoute(rg *gin.RouterGroup) {
+	router := rg.Group("game-summaries/:gameSummaryId/rounds")
+
+	router.POST("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionRoundsWrite), middleware.ScopeCasinos(), rc.roundController.CreateRound)
+	router.GET("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionRoundsRead), middleware.ScopeCasinos(), rc.roundController.FindRounds)
+	router.PUT("/:roundId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionRoundsWrite), middleware.ScopeCasinos(), rc.roundController.UpdateRound)
+}
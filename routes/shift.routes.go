@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/suidevv/tableye-api/controllers"
+	"github.com/suidevv/tableye-api/middleware"
+	"github.com/suidevv/tableye-api/models"
+)
+
+type ShiftRouteController struct {
+	shiftController controllers.ShiftController
+}
+
+func NewRouteShiftController(shiftController controllers.ShiftController) ShiftRouteController {
+	return ShiftRouteController{shiftController}
+}
+
+func (sc *ShiftRouteController) ShiftRoute(rg *gin.RouterGroup) {
+	router := rg.Group("shifts")
+
+	router.POST("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionShiftsPlan), middleware.ScopeCasinos(), sc.shiftController.CreateShift)
+	router.GET("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionShiftsRead), middleware.ScopeCasinos(), sc.shiftController.FindShifts)
+	router.DELETE("/:shiftId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionShiftsPlan), middleware.ScopeCasinos(), sc.shiftController.DeleteShift)
+	router.GET("/current", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionShiftsClock), middleware.ScopeCasinos(), sc.shiftController.FindCurrentShift)
+	router.POST("/clock-in", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionShiftsClock), middleware.ScopeCasinos(), sc.shiftController.ClockIn)
+	router.POST("/clock-out", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionShiftsClock), middleware.ScopeCasinos(), sc.shiftController.ClockOut)
+	router.POST("/breaks/start", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionShiftsClock), middleware.ScopeCasinos(), sc.shiftController.StartBreak)
+	router.POST("/breaks/end", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionShiftsClock), middleware.ScopeCasinos(), sc.shiftController.EndBreak)
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/suidevv/tableye-api/controllers"
+	"github.com/suidevv/tableye-api/middleware"
+	"github.com/suidevv/tableye-api/models"
+)
+
+type TableRouteController struct {
+	tableController controllers.TableController
+}
+
+func NewRouteTableController(tableController controllers.TableController) TableRouteController {
+	return TableRouteController{tableController}
+}
+
+func (tc *TableRouteController) TableRoute(rg *gin.RouterGroup) {
+	router := rg.Group("casinos/:casinoId/tables")
+
+	router.POST("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionTablesWrite), middleware.ScopeCasinos(), tc.tableController.CreateTable)
+	router.GET("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionTablesRead), middleware.ScopeCasinos(), tc.tableController.FindTables)
+	router.GET("/:tableId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionTablesRead), middleware.ScopeCasinos(), tc.tableController.FindTableById)
+	router.PUT("/:tableId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionTablesWrite), middleware.ScopeCasinos(), tc.tableController.UpdateTable)
+	router.DELETE("/:tableId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionTablesWrite), middleware.ScopeCasinos(), tc.tableController.DeleteTable)
+}
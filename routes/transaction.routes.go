@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/suidevv/tableye-api/controllers"
 	"github.com/suidevv/tableye-api/middleware"
+	"github.com/suidevv/tableye-api/models"
 )
 
 type TransactionRouteController struct {
@@ -17,9 +18,14 @@ func NewRouteTransactionController(transactionController controllers.Transaction
 func (tc *TransactionRouteController) TransactionRoute(rg *gin.RouterGroup) {
 	router := rg.Group("transactions")
 
-	router.POST("/", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin", "dealer"), tc.transactionController.CreateTransaction)
-	router.GET("/", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin", "dealer"), tc.transactionController.FindTransactions)
-	router.GET("/:transactionId", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin", "dealer"), tc.transactionController.FindTransactionById)
-	router.PUT("/:transactionId", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"), tc.transactionController.UpdateTransaction)
-	router.DELETE("/:transactionId", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"), tc.transactionController.DeleteTransaction)
+	router.POST("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionTransactionsCreate), middleware.ScopeCasinos(), tc.transactionController.CreateTransaction)
+	router.GET("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionTransactionsRead), middleware.ScopeCasinos(), tc.transactionController.FindTransactions)
+	router.GET("/:transactionId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionTransactionsRead), middleware.ScopeCasinos(), tc.transactionController.FindTransactionById)
+	router.POST("/:transactionId/corrections", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionTransactionsUpdate), middleware.ScopeCasinos(), tc.transactionController.CorrectTransaction)
+	router.DELETE("/:transactionId", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionTransactionsDelete), middleware.ScopeCasinos(), tc.transactionController.DeleteTransaction)
+
+	admin := rg.Group("admin/deleted/transactions")
+
+	admin.GET("/", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionDeletedRecordsManage), middleware.ScopeCasinos(), tc.transactionController.FindDeletedTransactions)
+	admin.POST("/:transactionId/restore", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionDeletedRecordsManage), middleware.ScopeCasinos(), tc.transactionController.RestoreTransaction)
 }
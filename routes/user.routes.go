@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/suidevv/tableye-api/controllers"
 	"github.com/suidevv/tableye-api/middleware"
+	"github.com/suidevv/tableye-api/models"
 )
 
 type UserRouteController struct {
@@ -17,5 +18,6 @@ func NewRouteUserController(userController controllers.UserController) UserRoute
 func (uc *UserRouteController) UserRoute(rg *gin.RouterGroup) {
 
 	router := rg.Group("users")
-	router.GET("/me", middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"), uc.userController.GetMe)
+	router.GET("/me", middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionProfileRead), uc.userController.GetMe)
+	router.GET("/me/permissions", middleware.DeserializeUser(), uc.userController.GetMyPermissions)
 }
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/suidevv/tableye-api/controllers"
+)
+
+type WellKnownRouteController struct {
+	wellKnownController controllers.WellKnownController
+}
+
+func NewRouteWellKnownController(wellKnownController controllers.WellKnownController) WellKnownRouteController {
+	return WellKnownRouteController{wellKnownController}
+}
+
+// WellKnownRoute registers the standard discovery documents. They live at
+// the server root rather than under /api.
+func (wc *WellKnownRouteController) WellKnownRoute(rg *gin.RouterGroup) {
+	router := rg.Group(".well-known")
+
+	router.GET("/jwks.json", wc.wellKnownController.FindJWKS)
+}
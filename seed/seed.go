@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/suidevv/tableye-api/controllers"
 	"github.com/suidevv/tableye-api/initializers"
 	"github.com/suidevv/tableye-api/models"
 	"golang.org/x/crypto/bcrypt"
@@ -44,14 +45,16 @@ func main() {
 	players := seedPlayers(db)
 	gameSummaries := seedGameSummaries(db, games, casinos, dealers)
 	seedTransactions(db, gameSummaries, players)
+	refreshDealerPerformance(db, dealers)
 
 	createRelationships(db, casinos, dealers, games, gameSummaries, players)
+	seedCasinoOwners(db, users, casinos)
 
 	fmt.Println("Seeding completed successfully!")
 }
 
 func clearTables(db *gorm.DB) {
-	tables := []string{"game_players", "casino_dealers", "casino_games", "transactions", "game_summaries", "players", "dealers", "games", "casinos", "users"}
+	tables := []string{"game_players", "casino_owners", "casino_dealers", "casino_games", "transactions", "rounds", "game_summaries", "tables", "players", "shift_breaks", "shifts", "dealers", "games", "casinos", "recovery_codes", "security_events", "audit_events", "user_tokens", "sessions", "users"}
 	for _, table := range tables {
 		if err := db.Exec(fmt.Sprintf("TRUNCATE TABLE %s CASCADE", table)).Error; err != nil {
 			log.Fatalf("Failed to clear table %s: %v", table, err)
@@ -115,8 +118,8 @@ func seedGames(db *gorm.DB) []models.Game {
 			Description: fmt.Sprintf("Description for %s %d", gameTypes[i], i+1),
 			MaxPlayers:  4 + rand.Intn(8),
 			MinPlayers:  1 + rand.Intn(3),
-			MinBet:      float64(5 + rand.Intn(20)),
-			MaxBet:      float64(100 + rand.Intn(900)),
+			MinBet:      models.Money(5+rand.Intn(20)) * 100,
+			MaxBet:      models.Money(100+rand.Intn(900)) * 100,
 		}
 	}
 	if err := db.Create(&games).Error; err != nil {
@@ -133,8 +136,6 @@ func seedDealers(db *gorm.DB, dealerUsers []models.User) []models.Dealer {
 			UserID:     dealerUsers[i].ID,
 			DealerCode: fmt.Sprintf("D%04d", i+1),
 			Status:     "Active",
-			GamesDealt: rand.Intn(200),
-			Rating:     4.0 + rand.Float32(),
 		}
 	}
 	if err := db.Create(&dealers).Error; err != nil {
@@ -150,7 +151,7 @@ func seedPlayers(db *gorm.DB) []models.Player {
 		players[i] = models.Player{
 			ID:            uuid.New(),
 			Nickname:      fmt.Sprintf("Player%d", i+1),
-			TotalWinnings: float64(rand.Intn(10000)),
+			TotalWinnings: 0,
 			Rank:          ranks[rand.Intn(len(ranks))],
 			Status:        "Active",
 		}
@@ -173,10 +174,10 @@ func seedGameSummaries(db *gorm.DB, games []models.Game, casinos []models.Casino
 			StartTime:    startTime,
 			EndTime:      endTime,
 			DealerID:     dealers[rand.Intn(len(dealers))].ID,
-			TotalPot:     float64(100 + rand.Intn(10000)),
-			Status:       []string{"Completed", "In Progress"}[rand.Intn(2)],
+			TotalPot:     models.Money(100+rand.Intn(10000)) * 100,
+			Status:       []string{models.GameSummaryStatusCompleted, models.GameSummaryStatusInProgress}[rand.Intn(2)],
 			RoundsPlayed: rand.Intn(50),
-			HighestBet:   float64(50 + rand.Intn(950)),
+			HighestBet:   models.Money(50+rand.Intn(950)) * 100,
 		}
 	}
 	if err := db.Create(&gameSummaries).Error; err != nil {
@@ -192,7 +193,8 @@ func seedTransactions(db *gorm.DB, gameSummaries []models.GameSummary, players [
 		player := players[rand.Intn(len(players))]
 		transactionTime := gameSummary.StartTime.Add(time.Duration(rand.Intn(int(gameSummary.EndTime.Sub(gameSummary.StartTime)))))
 		transactionType := []string{"bet", "win"}[rand.Intn(2)]
-		amount := float64(10 + rand.Intn(990))
+		// Amounts are in cents
+		amount := models.Money(1000 + rand.Intn(99000))
 		if transactionType == "bet" {
 			amount = -amount
 		}
@@ -210,6 +212,21 @@ func seedTransactions(db *gorm.DB, gameSummaries []models.GameSummary, players [
 	if err := db.Create(&transactions).Error; err != nil {
 		log.Fatalf("Failed to create transactions: %v", err)
 	}
+
+	// Keep player totals in line with the ledger
+	if err := db.Exec(`UPDATE players SET total_winnings = COALESCE((SELECT SUM(amount) FROM transactions WHERE transactions.player_id = players.id), 0)`).Error; err != nil {
+		log.Fatalf("Failed to update player winnings: %v", err)
+	}
+}
+
+// refreshDealerPerformance derives the dealers' games dealt and rating from the
+// seeded sessions, the same way the API keeps them up to date.
+func refreshDealerPerformance(db *gorm.DB, dealers []models.Dealer) {
+	for _, dealer := range dealers {
+		if err := controllers.RefreshDealerPerformance(db, dealer.ID); err != nil {
+			log.Fatalf("Failed to refresh performance of dealer %s: %v", dealer.DealerCode, err)
+		}
+	}
 }
 
 func createRelationships(db *gorm.DB, casinos []models.Casino, dealers []models.Dealer, games []models.Game, gameSummaries []models.GameSummary, players []models.Player) {
@@ -278,3 +295,23 @@ func hashPassword(password string) string {
 	}
 	return string(hashedPassword)
 }
+
+// seedCasinoOwners hands each casino to one of the casino_owner users in turn.
+func seedCasinoOwners(db *gorm.DB, users []models.User, casinos []models.Casino) {
+	var owners []models.User
+	for _, user := range users {
+		if user.Role == "casino_owner" {
+			owners = append(owners, user)
+		}
+	}
+	if len(owners) == 0 {
+		return
+	}
+
+	for i, casino := range casinos {
+		owner := owners[i%len(owners)]
+		if err := db.Exec("INSERT INTO casino_owners (casino_id, user_id) VALUES (?, ?)", casino.ID, owner.ID).Error; err != nil {
+			log.Printf("Failed to create casino-owner relationship: %v", err)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+	"github.com/suidevv/tableye-api/utils"
+)
+
+func TestAuditLog(t *testing.T) {
+	router := GetTestRouter()
+
+	request := func(method, path, accessToken, requestID string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		if requestID != "" {
+			req.Header.Set("X-Request-ID", requestID)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	dealer := signIn(t, "user12@example.com", "password12")
+	admin := signIn(t, "user13@example.com", "password13")
+
+	t.Run("RecordsChanges", func(t *testing.T) {
+		path := "/api/admin/roles/dealer/permissions/" + models.PermissionPlayersStats
+
+		w := request("POST", path, admin.AccessToken, "audit-test-grant")
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "audit-test-grant", w.Header().Get("X-Request-ID"))
+
+		w = request("DELETE", path, admin.AccessToken, "")
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.NotEmpty(t, w.Header().Get("X-Request-ID"))
+
+		w = request("GET", "/api/admin/audit?entity_type=role_permission&request_id=audit-test-grant", admin.AccessToken, "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data []models.AuditEvent `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		if assert.Len(t, response.Data, 1) {
+			event := response.Data[0]
+			assert.Equal(t, models.AuditActionCreate, event.Action)
+			assert.Equal(t, "dealer:"+models.PermissionPlayersStats, event.EntityID)
+			if assert.NotNil(t, event.ActorID) {
+				assert.Equal(t, admin.User.ID, *event.ActorID)
+			}
+			assert.Equal(t, "admin", event.ActorRole)
+			assert.JSONEq(t, `{"permission":{"new":"`+models.PermissionPlayersStats+`"},"role":{"new":"dealer"}}`, string(event.Changes))
+		}
+	})
+
+	t.Run("RecordsAccountChanges", func(t *testing.T) {
+		// A user of their own, so starting 2FA enrolment does not affect other tests
+		suffix := time.Now().UnixNano()
+		hashedPassword, _ := utils.HashPassword("password123")
+		user := models.User{ID: uuid.New(), Name: "Audit User", Email: fmt.Sprintf("audit%d@example.com", suffix), Password: hashedPassword, Role: "dealer", Provider: "local", Verified: true}
+		if err := testDB.Create(&user).Error; err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		signedIn := signIn(t, user.Email, "password123")
+
+		findEvents := func(t *testing.T, entityType, requestID string) []models.AuditEvent {
+			w := request("GET", "/api/admin/audit?entity_type="+entityType+"&request_id="+requestID, admin.AccessToken, "")
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response struct {
+				Data []models.AuditEvent `json:"data"`
+			}
+			json.Unmarshal(w.Body.Bytes(), &response)
+			return response.Data
+		}
+
+		requestID := fmt.Sprintf("audit-test-2fa-%d", suffix)
+		w := request("POST", "/api/auth/2fa/setup", signedIn.AccessToken, requestID)
+		assert.Equal(t, http.StatusOK, w.Code)
+		if events := findEvents(t, "two_factor", requestID); assert.Len(t, events, 1) {
+			assert.Equal(t, user.ID.String(), events[0].EntityID)
+			assert.NotContains(t, string(events[0].Changes), "totp_secret")
+		}
+
+		requestID = fmt.Sprintf("audit-test-unlock-%d", suffix)
+		w = request("POST", "/api/admin/users/"+user.ID.String()+"/unlock", admin.AccessToken, requestID)
+		assert.Equal(t, http.StatusOK, w.Code)
+		if events := findEvents(t, "account_lock", requestID); assert.Len(t, events, 1) {
+			assert.Equal(t, models.AuditActionDelete, events[0].Action)
+			assert.Equal(t, user.ID.String(), events[0].EntityID)
+		}
+
+		var session models.Session
+		testDB.Where("user_id = ? AND revoked_at IS NULL", user.ID).First(&session)
+		requestID = fmt.Sprintf("audit-test-revoke-%d", suffix)
+		w = request("DELETE", "/api/auth/sessions/"+session.ID.String(), signedIn.AccessToken, requestID)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		if events := findEvents(t, "session", requestID); assert.Len(t, events, 1) {
+			assert.Equal(t, session.ID.String(), events[0].EntityID)
+			assert.Contains(t, string(events[0].Changes), "revoked_at")
+		}
+	})
+
+	t.Run("AdminOnly", func(t *testing.T) {
+		w := request("GET", "/api/admin/audit", dealer.AccessToken, "")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("ChainVerifies", func(t *testing.T) {
+		w := request("GET", "/api/admin/audit/verify", admin.AccessToken, "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data struct {
+				Valid   bool  `json:"valid"`
+				Checked int64 `json:"checked"`
+			} `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.True(t, response.Data.Valid)
+		assert.Positive(t, response.Data.Checked)
+	})
+
+	t.Run("AppendOnly", func(t *testing.T) {
+		err := testDB.Exec("UPDATE audit_events SET actor_role = 'dealer'").Error
+		assert.Error(t, err)
+		err = testDB.Exec("DELETE FROM audit_events").Error
+		assert.Error(t, err)
+	})
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/suidevv/tableye-api/models"
+	"github.com/suidevv/tableye-api/utils"
 )
 
 func TestSignUpUser(t *testing.T) {
@@ -90,7 +91,7 @@ func TestSignInUser(t *testing.T) {
 		req.Header.Set("Content-Type", "application/json")
 		router.ServeHTTP(w, req)
 
-		if w.Code == http.StatusBadRequest {
+		if w.Code == http.StatusBadRequest || w.Code == http.StatusForbidden {
 			// User doesn't exist, create one
 			signUpPayload := models.SignUpInput{
 				Name:            "Test User",
@@ -105,6 +106,9 @@ func TestSignInUser(t *testing.T) {
 			router.ServeHTTP(w, req)
 			assert.Equal(t, http.StatusCreated, w.Code)
 		}
+
+		// New users are unverified until they follow the mailed link
+		testDB.Model(&models.User{}).Where("email = ?", email).Update("verified", true)
 	}
 
 	t.Run("Successful SignIn", func(t *testing.T) {
@@ -149,3 +153,348 @@ func TestSignInUser(t *testing.T) {
 		assert.Equal(t, "Invalid email or Password", response["message"])
 	})
 }
+
+func TestRefreshTokenRotation(t *testing.T) {
+	router := GetTestRouter()
+
+	refreshCookie := func(w *httptest.ResponseRecorder) *http.Cookie {
+		for _, cookie := range w.Result().Cookies() {
+			if cookie.Name == "refresh_token" {
+				return cookie
+			}
+		}
+		return nil
+	}
+
+	refresh := func(cookie *http.Cookie) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/auth/refresh", nil)
+		req.AddCookie(cookie)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	jsonSignInPayload, _ := json.Marshal(models.SignInInput{Email: "user13@example.com", Password: "password13"})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/auth/login", bytes.NewBuffer(jsonSignInPayload))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	firstToken := refreshCookie(w)
+	if firstToken == nil {
+		t.Fatal("Sign in did not set a refresh token")
+	}
+
+	w = refresh(firstToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+	secondToken := refreshCookie(w)
+	if secondToken == nil {
+		t.Fatal("Refresh did not rotate the refresh token")
+	}
+	assert.NotEqual(t, firstToken.Value, secondToken.Value)
+
+	// The refreshed access token is accepted by the API
+	var refreshResponse struct {
+		AccessToken string `json:"access_token"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &refreshResponse)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/users/me/permissions", nil)
+	req.Header.Set("Authorization", "Bearer "+refreshResponse.AccessToken)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Replaying the first token revokes the session, so the second stops working too
+	w = refresh(firstToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = refresh(secondToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestEmailVerificationAndPasswordReset(t *testing.T) {
+	router := GetTestRouter()
+
+	post := func(path string, payload interface{}) *httptest.ResponseRecorder {
+		jsonPayload, _ := json.Marshal(payload)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", path, bytes.NewBuffer(jsonPayload))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Mailed secrets are only stored hashed, so plant a known one for the user
+	issueToken := func(email, purpose string, expiresAt time.Time) string {
+		var user models.User
+		testDB.First(&user, "email = ?", email)
+		secret, _ := utils.GenerateSecret()
+		testDB.Create(&models.UserToken{
+			UserID:    user.ID,
+			Purpose:   purpose,
+			TokenHash: utils.HashSecret(secret),
+			ExpiresAt: expiresAt,
+			CreatedAt: time.Now(),
+		})
+		return secret
+	}
+
+	email := fmt.Sprintf("verify%d@example.com", time.Now().UnixNano())
+	password := "password123"
+	w := post("/api/auth/register", models.SignUpInput{Name: "Test User", Email: email, Password: password, PasswordConfirm: password})
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	t.Run("Unverified users cannot sign in", func(t *testing.T) {
+		w := post("/api/auth/login", models.SignInInput{Email: email, Password: password})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Verification token is single use", func(t *testing.T) {
+		secret := issueToken(email, models.TokenPurposeEmailVerification, time.Now().Add(time.Hour))
+
+		w := post("/api/auth/verify-email", models.VerifyEmailInput{Token: secret})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = post("/api/auth/verify-email", models.VerifyEmailInput{Token: secret})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = post("/api/auth/login", models.SignInInput{Email: email, Password: password})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Forgot password answers the same for unknown addresses", func(t *testing.T) {
+		w := post("/api/auth/forgot-password", models.EmailInput{Email: "nobody@example.com"})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = post("/api/auth/forgot-password", models.EmailInput{Email: email})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Forgot password is rate limited per address", func(t *testing.T) {
+		target := fmt.Sprintf("mailbomb%d@example.com", time.Now().UnixNano())
+
+		var w *httptest.ResponseRecorder
+		for i := 0; i < 10; i++ {
+			if w = post("/api/auth/forgot-password", models.EmailInput{Email: target}); w.Code != http.StatusOK {
+				break
+			}
+		}
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	})
+
+	t.Run("Expired reset token is rejected", func(t *testing.T) {
+		secret := issueToken(email, models.TokenPurposePasswordReset, time.Now().Add(-time.Minute))
+		w := post("/api/auth/reset-password", models.ResetPasswordInput{Token: secret, Password: "newpassword123", PasswordConfirm: "newpassword123"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Verification token cannot reset the password", func(t *testing.T) {
+		secret := issueToken(email, models.TokenPurposeEmailVerification, time.Now().Add(time.Hour))
+		w := post("/api/auth/reset-password", models.ResetPasswordInput{Token: secret, Password: "newpassword123", PasswordConfirm: "newpassword123"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Reset token sets a new password once", func(t *testing.T) {
+		secret := issueToken(email, models.TokenPurposePasswordReset, time.Now().Add(time.Hour))
+		w := post("/api/auth/reset-password", models.ResetPasswordInput{Token: secret, Password: "newpassword123", PasswordConfirm: "newpassword123"})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = post("/api/auth/reset-password", models.ResetPasswordInput{Token: secret, Password: "otherpassword123", PasswordConfirm: "otherpassword123"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = post("/api/auth/login", models.SignInInput{Email: email, Password: password})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = post("/api/auth/login", models.SignInInput{Email: email, Password: "newpassword123"})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestLoginLockout(t *testing.T) {
+	router := GetTestRouter()
+
+	post := func(path string, payload interface{}) *httptest.ResponseRecorder {
+		jsonPayload, _ := json.Marshal(payload)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", path, bytes.NewBuffer(jsonPayload))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	request := func(method, path, accessToken string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	email := fmt.Sprintf("lockout%d@example.com", time.Now().UnixNano())
+	password := "password123"
+	w := post("/api/auth/register", models.SignUpInput{Name: "Test User", Email: email, Password: password, PasswordConfirm: password})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	testDB.Model(&models.User{}).Where("email = ?", email).Update("verified", true)
+
+	var user models.User
+	testDB.First(&user, "email = ?", email)
+
+	// The default policy allows five free failures; the sixth locks the account
+	for i := 0; i < 6; i++ {
+		w = post("/api/auth/login", models.SignInInput{Email: email, Password: "wrongpassword"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+
+	w = post("/api/auth/login", models.SignInInput{Email: email, Password: password})
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	admin := signInAdmin(t).AccessToken
+
+	t.Run("Lockout is recorded", func(t *testing.T) {
+		w := request("GET", "/api/admin/security-events?type=account_locked&user_id="+user.ID.String(), admin)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data []models.SecurityEvent `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		if assert.Len(t, response.Data, 1) {
+			assert.Equal(t, email, response.Data[0].Email)
+			assert.NotNil(t, response.Data[0].LockedUntil)
+		}
+	})
+
+	t.Run("Admin unlocks the account", func(t *testing.T) {
+		w := request("POST", "/api/admin/users/"+user.ID.String()+"/unlock", admin)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = post("/api/auth/login", models.SignInInput{Email: email, Password: password})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = request("GET", "/api/admin/security-events?type=account_unlocked&user_id="+user.ID.String(), admin)
+		var response struct {
+			Total int64 `json:"total"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, int64(1), response.Total)
+	})
+}
+
+func TestTwoFactorSignIn(t *testing.T) {
+	router := GetTestRouter()
+
+	post := func(path, accessToken string, payload interface{}) *httptest.ResponseRecorder {
+		jsonPayload, _ := json.Marshal(payload)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", path, bytes.NewBuffer(jsonPayload))
+		req.Header.Set("Content-Type", "application/json")
+		if accessToken != "" {
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	codeAt := func(secret string, offset int64) string {
+		code, _ := utils.TOTPCode(secret, utils.TOTPStep(time.Now())+offset)
+		return code
+	}
+
+	email := fmt.Sprintf("totp%d@example.com", time.Now().UnixNano())
+	password := "password123"
+	w := post("/api/auth/register", "", models.SignUpInput{Name: "Test User", Email: email, Password: password, PasswordConfirm: password})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	testDB.Model(&models.User{}).Where("email = ?", email).Update("verified", true)
+
+	w = post("/api/auth/login", "", models.SignInInput{Email: email, Password: password})
+	assert.Equal(t, http.StatusOK, w.Code)
+	var signInResponse models.SignInResponse
+	json.Unmarshal(w.Body.Bytes(), &signInResponse)
+
+	w = post("/api/auth/2fa/setup", signInResponse.AccessToken, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var setupResponse struct {
+		Data models.TwoFactorSetupResponse `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &setupResponse)
+	secret := setupResponse.Data.Secret
+	assert.Contains(t, setupResponse.Data.ProvisioningURI, "otpauth://totp/")
+
+	w = post("/api/auth/2fa/enable", signInResponse.AccessToken, models.TwoFactorCodeInput{Code: "000000"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = post("/api/auth/2fa/enable", signInResponse.AccessToken, models.TwoFactorCodeInput{Code: codeAt(secret, 0)})
+	assert.Equal(t, http.StatusOK, w.Code)
+	var enableResponse struct {
+		Data struct {
+			RecoveryCodes []string `json:"recovery_codes"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &enableResponse)
+	assert.Len(t, enableResponse.Data.RecoveryCodes, 10)
+
+	challenge := func() string {
+		w := post("/api/auth/login", "", models.SignInInput{Email: email, Password: password})
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		var response models.TwoFactorChallengeResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.True(t, response.TwoFactorRequired)
+		return response.ChallengeToken
+	}
+
+	t.Run("Challenge token is not an access token", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/users/me/permissions", nil)
+		req.Header.Set("Authorization", "Bearer "+challenge())
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Code completes the sign-in once", func(t *testing.T) {
+		token := challenge()
+
+		// The enrolment used the current step, so use the next one
+		w := post("/api/auth/2fa/verify", "", models.TwoFactorVerifyInput{ChallengeToken: token, Code: codeAt(secret, 1)})
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.SignInResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NotEmpty(t, response.AccessToken)
+
+		// The challenge is spent, whatever code comes with it
+		w = post("/api/auth/2fa/verify", "", models.TwoFactorVerifyInput{ChallengeToken: token, Code: codeAt(secret, 1)})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		w = post("/api/auth/2fa/verify", "", models.TwoFactorVerifyInput{ChallengeToken: token, Code: enableResponse.Data.RecoveryCodes[9]})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Wrong code leaves the challenge usable", func(t *testing.T) {
+		token := challenge()
+
+		w := post("/api/auth/2fa/verify", "", models.TwoFactorVerifyInput{ChallengeToken: token, Code: "000000"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = post("/api/auth/2fa/verify", "", models.TwoFactorVerifyInput{ChallengeToken: token, Code: enableResponse.Data.RecoveryCodes[8]})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("New sign-in replaces the challenge", func(t *testing.T) {
+		token := challenge()
+		challenge()
+
+		w := post("/api/auth/2fa/verify", "", models.TwoFactorVerifyInput{ChallengeToken: token, Code: enableResponse.Data.RecoveryCodes[7]})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Recovery codes work once", func(t *testing.T) {
+		recoveryCode := enableResponse.Data.RecoveryCodes[0]
+
+		w := post("/api/auth/2fa/verify", "", models.TwoFactorVerifyInput{ChallengeToken: challenge(), Code: recoveryCode})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = post("/api/auth/2fa/verify", "", models.TwoFactorVerifyInput{ChallengeToken: challenge(), Code: recoveryCode})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,159 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestCasinoAssignments(t *testing.T) {
+	admin := signInAdmin(t)
+	owner := signIn(t, "user2@example.com", "password2")
+
+	suffix := time.Now().UnixNano()
+	casino := models.Casino{ID: uuid.New(), Name: fmt.Sprintf("Assignment Casino %d", suffix), Location: "Elsewhere", LicenseNumber: fmt.Sprintf("LA%d", suffix), MaxCapacity: 100, Status: "Active"}
+	game := models.Game{ID: uuid.New(), Name: fmt.Sprintf("Assignment Game %d", suffix), Type: "Blackjack", MaxPlayers: 7, MinPlayers: 1, MinBet: 1_00, MaxBet: 1000_00}
+	user := models.User{ID: uuid.New(), Name: "Assignment Dealer", Email: fmt.Sprintf("assignment%d@example.com", suffix), Password: "x", Role: "dealer", Provider: "local", Verified: true}
+	dealer := models.Dealer{ID: uuid.New(), UserID: user.ID, DealerCode: fmt.Sprintf("A%d", suffix), Status: "active"}
+	for _, record := range []interface{}{&casino, &game, &user, &dealer} {
+		if err := testDB.Create(record).Error; err != nil {
+			t.Fatalf("Failed to create %T: %v", record, err)
+		}
+	}
+
+	casinoPath := "/api/casinos/" + casino.ID.String()
+	gamePath := casinoPath + "/games/" + game.ID.String()
+	dealerPath := casinoPath + "/dealers/" + dealer.ID.String()
+
+	// listed returns whether a listing contains the given ID
+	listed := func(t *testing.T, path string, id uuid.UUID) bool {
+		w := authedRequest(admin.AccessToken, "GET", path, nil)
+		if !assert.Equal(t, http.StatusOK, w.Code, path) {
+			return false
+		}
+
+		var response struct {
+			Data []struct {
+				ID uuid.UUID `json:"id"`
+			} `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		for _, item := range response.Data {
+			if item.ID == id {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("AssignGame", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "POST", gamePath, nil)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		w = authedRequest(admin.AccessToken, "POST", gamePath, nil)
+		assert.Equal(t, http.StatusConflict, w.Code, "game is already offered")
+
+		assert.True(t, listed(t, casinoPath+"/games", game.ID))
+		assert.True(t, listed(t, "/api/games/"+game.ID.String()+"/casinos", casino.ID))
+	})
+
+	t.Run("UnassignGame", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "DELETE", gamePath, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = authedRequest(admin.AccessToken, "DELETE", gamePath, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, "game is no longer offered")
+
+		assert.False(t, listed(t, casinoPath+"/games", game.ID))
+		assert.False(t, listed(t, "/api/games/"+game.ID.String()+"/casinos", casino.ID))
+	})
+
+	t.Run("AssignDealer", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "POST", dealerPath, nil)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		w = authedRequest(admin.AccessToken, "POST", dealerPath, nil)
+		assert.Equal(t, http.StatusConflict, w.Code, "dealer is already assigned")
+
+		assert.True(t, listed(t, casinoPath+"/dealers", dealer.ID))
+		assert.True(t, listed(t, "/api/dealers/"+dealer.ID.String()+"/casinos", casino.ID))
+	})
+
+	t.Run("UnassignDealer", func(t *testing.T) {
+		now := time.Now()
+		shift := models.Shift{ID: uuid.New(), DealerID: dealer.ID, CasinoID: casino.ID, ClockInAt: &now, Status: models.ShiftStatusActive}
+		if err := testDB.Create(&shift).Error; err != nil {
+			t.Fatalf("Failed to clock in dealer: %v", err)
+		}
+		w := authedRequest(admin.AccessToken, "DELETE", dealerPath, nil)
+		assert.Equal(t, http.StatusConflict, w.Code, "dealer is clocked in")
+
+		testDB.Model(&shift).Updates(map[string]interface{}{"status": models.ShiftStatusCompleted, "clock_out_at": time.Now()})
+		w = authedRequest(admin.AccessToken, "DELETE", dealerPath, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = authedRequest(admin.AccessToken, "DELETE", dealerPath, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, "dealer is no longer assigned")
+
+		assert.False(t, listed(t, casinoPath+"/dealers", dealer.ID))
+		assert.False(t, listed(t, "/api/dealers/"+dealer.ID.String()+"/casinos", casino.ID))
+	})
+
+	t.Run("UnknownIDs", func(t *testing.T) {
+		unknownCasino := "/api/casinos/" + uuid.New().String()
+		for _, path := range []string{
+			casinoPath + "/games/" + uuid.New().String(),
+			casinoPath + "/dealers/" + uuid.New().String(),
+			unknownCasino + "/games/" + game.ID.String(),
+			unknownCasino + "/dealers/" + dealer.ID.String(),
+		} {
+			w := authedRequest(admin.AccessToken, "POST", path, nil)
+			assert.Equal(t, http.StatusNotFound, w.Code, path)
+		}
+
+		for _, path := range []string{
+			unknownCasino + "/games",
+			unknownCasino + "/dealers",
+			"/api/games/" + uuid.New().String() + "/casinos",
+			"/api/dealers/" + uuid.New().String() + "/casinos",
+		} {
+			w := authedRequest(admin.AccessToken, "GET", path, nil)
+			assert.Equal(t, http.StatusNotFound, w.Code, path)
+		}
+	})
+
+	t.Run("OwnerOfAnotherCasino", func(t *testing.T) {
+		w := authedRequest(owner.AccessToken, "POST", gamePath, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = authedRequest(owner.AccessToken, "POST", dealerPath, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = authedRequest(owner.AccessToken, "GET", casinoPath+"/games", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		assert.False(t, listed(t, casinoPath+"/games", game.ID))
+		assert.False(t, listed(t, casinoPath+"/dealers", dealer.ID))
+	})
+
+	t.Run("OwnerOfThisCasino", func(t *testing.T) {
+		ownershipPath := "/api/admin/casinos/" + casino.ID.String() + "/owners/" + owner.User.ID.String()
+		w := authedRequest(admin.AccessToken, "POST", ownershipPath, nil)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		defer authedRequest(admin.AccessToken, "DELETE", ownershipPath, nil)
+
+		w = authedRequest(owner.AccessToken, "POST", gamePath, nil)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.True(t, listed(t, casinoPath+"/games", game.ID))
+
+		w = authedRequest(owner.AccessToken, "DELETE", gamePath, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.False(t, listed(t, casinoPath+"/games", game.ID))
+	})
+}
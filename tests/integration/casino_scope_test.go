@@ -0,0 +1,135 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestCasinoScoping(t *testing.T) {
+	// Seeded user12 has the dealer role, user13 is an admin, user2 is a casino owner
+	dealer := signIn(t, "user12@example.com", "password12")
+	admin := signIn(t, "user13@example.com", "password13")
+	owner := signIn(t, "user2@example.com", "password2")
+	if dealer.Dealer == nil {
+		t.Fatal("Seeded dealer user has no dealer profile")
+	}
+
+	// A casino the dealer is not assigned to
+	otherCasino := models.Casino{
+		ID:            uuid.New(),
+		Name:          fmt.Sprintf("Other Casino %d", time.Now().UnixNano()),
+		Location:      "Elsewhere",
+		LicenseNumber: fmt.Sprintf("LN%d", time.Now().UnixNano()),
+		MaxCapacity:   100,
+		Status:        "Active",
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+	if err := testDB.Create(&otherCasino).Error; err != nil {
+		t.Fatalf("Failed to create casino: %v", err)
+	}
+	otherCasinoID := otherCasino.ID.String()
+
+	t.Run("DealerCannotReadOtherCasino", func(t *testing.T) {
+		w := authedRequest(dealer.AccessToken, "GET", "/api/casinos/"+otherCasinoID, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = authedRequest(dealer.AccessToken, "GET", "/api/casinos/"+otherCasinoID+"/tables", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("AdminCanReadOtherCasino", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "GET", "/api/casinos/"+otherCasinoID, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("DealerListsOnlyOwnCasinos", func(t *testing.T) {
+		w := authedRequest(dealer.AccessToken, "GET", "/api/casinos/?limit=100", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		allowed := make(map[string]bool)
+		for _, casino := range dealer.Casinos {
+			allowed[casino.ID.String()] = true
+		}
+
+		var response struct {
+			Data []map[string]interface{} `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		for _, casino := range response.Data {
+			assert.True(t, allowed[casino["id"].(string)], "Dealer can see casino %v", casino["id"])
+		}
+	})
+
+	t.Run("DealerCannotOpenSessionAtOtherCasino", func(t *testing.T) {
+		payload := models.CreateGameSummaryRequest{
+			GameID:    uuid.New().String(),
+			CasinoID:  otherCasinoID,
+			StartTime: time.Now(),
+			DealerID:  dealer.Dealer.ID.String(),
+			PlayerIDs: []string{uuid.New().String()},
+		}
+		w := authedRequest(dealer.AccessToken, "POST", "/api/game-summaries/", payload)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("DealerCannotReadOtherCasinoSessions", func(t *testing.T) {
+		var gameSummary models.GameSummary
+		if err := testDB.Where("casino_id NOT IN (SELECT casino_id FROM casino_dealers WHERE dealer_id = ?)", dealer.Dealer.ID).
+			First(&gameSummary).Error; err != nil {
+			t.Skip("No session outside the dealer's casinos")
+		}
+
+		w := authedRequest(dealer.AccessToken, "POST", "/api/game-summaries/"+gameSummary.ID.String()+"/pause", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = authedRequest(dealer.AccessToken, "GET", "/api/transactions/?game_summary_id="+gameSummary.ID.String(), nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Results int `json:"results"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, 0, response.Results)
+	})
+
+	t.Run("OwnerGainsAccessWhenGrantedOwnership", func(t *testing.T) {
+		ownershipPath := "/api/admin/casinos/" + otherCasinoID + "/owners/" + owner.User.ID.String()
+
+		w := authedRequest(owner.AccessToken, "GET", "/api/casinos/"+otherCasinoID, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = authedRequest(admin.AccessToken, "POST", ownershipPath, nil)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		w = authedRequest(admin.AccessToken, "POST", ownershipPath, nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = authedRequest(owner.AccessToken, "GET", "/api/casinos/"+otherCasinoID, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = authedRequest(owner.AccessToken, "GET", "/api/casinos/"+otherCasinoID+"/tables", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = authedRequest(owner.AccessToken, "POST", "/api/casinos/", nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = authedRequest(admin.AccessToken, "DELETE", ownershipPath, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = authedRequest(owner.AccessToken, "GET", "/api/casinos/"+otherCasinoID, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("AdminCannotBeGrantedOwnership", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "POST", "/api/admin/casinos/"+otherCasinoID+"/owners/"+admin.User.ID.String(), nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
@@ -0,0 +1,150 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestCasinoCurrencies(t *testing.T) {
+	admin := signInAdmin(t)
+
+	// A US casino with one session in 2000, long before any real rates
+	suffix := time.Now().UnixNano()
+	casino := models.Casino{
+		ID:            uuid.New(),
+		Name:          fmt.Sprintf("Dollar Casino %d", suffix),
+		Location:      "Las Vegas",
+		LicenseNumber: fmt.Sprintf("USD-%d", suffix),
+		MaxCapacity:   100,
+		Status:        "active",
+		Currency:      "USD",
+	}
+	game := models.Game{
+		ID:         uuid.New(),
+		Name:       fmt.Sprintf("Currency Game %d", suffix),
+		Type:       "Poker",
+		MaxPlayers: 8,
+		MinPlayers: 2,
+		MinBet:     1_00,
+		MaxBet:     1000_00,
+	}
+	player := models.Player{ID: uuid.New(), Nickname: fmt.Sprintf("currency-%d", suffix), Status: "active"}
+	gameSummary := models.GameSummary{
+		ID:        uuid.New(),
+		GameID:    game.ID,
+		CasinoID:  casino.ID,
+		DealerID:  admin.Dealer.ID,
+		Players:   []models.Player{player},
+		StartTime: time.Date(2000, 6, 1, 20, 0, 0, 0, time.UTC),
+		Status:    models.GameSummaryStatusInProgress,
+		Currency:  casino.Currency,
+	}
+	// A session from before any USD rate, at a table that overrides both limits
+	tableMinBet, tableMaxBet := models.Money(5_00), models.Money(50_00)
+	table := models.Table{ID: uuid.New(), CasinoID: casino.ID, TableNumber: "D1", GameID: game.ID, MinBet: &tableMinBet, MaxBet: &tableMaxBet, Status: models.TableStatusOpen}
+	tableSession := gameSummary
+	tableSession.ID = uuid.New()
+	tableSession.TableID = &table.ID
+	tableSession.StartTime = time.Date(1999, 6, 1, 20, 0, 0, 0, time.UTC)
+	for _, record := range []interface{}{&casino, &game, &player, &gameSummary, &table, &tableSession} {
+		if err := testDB.Create(record).Error; err != nil {
+			t.Fatalf("Failed to create %T: %v", record, err)
+		}
+	}
+
+	t.Run("BookingNeedsARate", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "POST", "/api/transactions/", models.CreateTransactionRequest{
+			GameSummaryID: gameSummary.ID.String(), PlayerID: player.ID.String(), Amount: 100_00, Outcome: "win",
+		})
+		if w.Code == http.StatusCreated {
+			t.Skip("An earlier run already set the USD rate for 2000")
+		}
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("TableLimitsNeedNoRate", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "POST", "/api/transactions/", models.CreateTransactionRequest{
+			GameSummaryID: tableSession.ID.String(), PlayerID: player.ID.String(), Amount: 100_00, Outcome: "win",
+		})
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+		var response map[string]interface{}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, models.RuleMaxBet, response["rule"])
+	})
+
+	t.Run("SetExchangeRate", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "PUT", "/api/admin/exchange-rates", models.SetExchangeRateRequest{Currency: "USD", Date: "2000-01-01", Rate: models.RateOne / 2})
+		assert.Contains(t, []int{http.StatusCreated, http.StatusOK}, w.Code)
+
+		w = authedRequest(admin.AccessToken, "PUT", "/api/admin/exchange-rates", models.SetExchangeRateRequest{Currency: models.DefaultCurrency, Date: "2000-01-01", Rate: 2 * models.RateOne})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = authedRequest(admin.AccessToken, "PUT", "/api/admin/exchange-rates", models.SetExchangeRateRequest{Currency: "XYZ", Date: "2000-01-01", Rate: 2 * models.RateOne})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("TransactionInheritsCurrency", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "POST", "/api/transactions/", models.CreateTransactionRequest{
+			GameSummaryID: gameSummary.ID.String(), PlayerID: player.ID.String(), Amount: 100_00, Outcome: "win",
+		})
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var response struct {
+			Data models.TransactionResponse `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "USD", response.Data.Currency)
+		assert.Equal(t, models.Money(100_00), response.Data.Amount)
+
+		// Player totals span casinos and are kept in the reporting currency
+		var stored models.Player
+		testDB.First(&stored, "id = ?", player.ID)
+		assert.Equal(t, models.Money(50_00), stored.TotalWinnings)
+	})
+
+	t.Run("ReportsConvert", func(t *testing.T) {
+		var report struct {
+			Currency string                       `json:"currency"`
+			Data     []models.CasinoRevenueReport `json:"data"`
+		}
+
+		w := authedRequest(admin.AccessToken, "GET", "/api/reports/revenue?casino_id="+casino.ID.String(), nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &report)
+		assert.Equal(t, "USD", report.Currency)
+		if assert.Len(t, report.Data, 1) {
+			assert.Equal(t, "USD", report.Data[0].Currency)
+			assert.Equal(t, models.Money(-100_00), report.Data[0].GrossGamingRevenue)
+		}
+
+		w = authedRequest(admin.AccessToken, "GET", "/api/reports/revenue?currency=eur&casino_id="+casino.ID.String(), nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &report)
+		assert.Equal(t, models.DefaultCurrency, report.Currency)
+		if assert.Len(t, report.Data, 1) {
+			assert.Equal(t, models.DefaultCurrency, report.Data[0].Currency)
+			assert.Equal(t, models.Money(-50_00), report.Data[0].GrossGamingRevenue)
+			assert.Equal(t, models.Money(50_00), report.Data[0].SettledVolume)
+		}
+
+		// No rate was ever set for the Mongolian tögrög
+		w = authedRequest(admin.AccessToken, "GET", "/api/reports/revenue?currency=MNT&casino_id="+casino.ID.String(), nil)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("CurrencyIsFixedOnceInUse", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "PUT", "/api/casinos/"+casino.ID.String(), models.UpdateCasinoRequest{Currency: "GBP"})
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = authedRequest(admin.AccessToken, "PUT", "/api/casinos/"+casino.ID.String(), models.UpdateCasinoRequest{Currency: "USD"})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
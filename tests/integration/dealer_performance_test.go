@@ -0,0 +1,94 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestDealerPerformance(t *testing.T) {
+	admin := signInAdmin(t)
+
+	suffix := time.Now().UnixNano()
+	user := models.User{ID: uuid.New(), Name: "Performance Dealer", Email: fmt.Sprintf("performance%d@example.com", suffix), Password: "x", Provider: "local", Verified: true}
+	dealer := models.Dealer{ID: uuid.New(), UserID: user.ID, DealerCode: fmt.Sprintf("P%d", suffix), Status: "active"}
+	game := models.Game{ID: uuid.New(), Name: fmt.Sprintf("Performance Game %d", suffix), Type: "Blackjack", MaxPlayers: 7, MinPlayers: 1, MinBet: 1_00, MaxBet: 1000_00}
+	player := models.Player{ID: uuid.New(), Nickname: fmt.Sprintf("performance-%d", suffix), Status: "active"}
+	for _, record := range []interface{}{&user, &dealer, &game, &player} {
+		if err := testDB.Create(record).Error; err != nil {
+			t.Fatalf("Failed to create %T: %v", record, err)
+		}
+	}
+
+	createSession := func(status string, start time.Time, end time.Time, rounds int) models.GameSummary {
+		gameSummary := models.GameSummary{
+			ID:           uuid.New(),
+			GameID:       game.ID,
+			CasinoID:     admin.Casinos[0].ID,
+			DealerID:     dealer.ID,
+			Players:      []models.Player{player},
+			StartTime:    start,
+			EndTime:      end,
+			RoundsPlayed: rounds,
+			Status:       status,
+		}
+		if err := testDB.Create(&gameSummary).Error; err != nil {
+			t.Fatalf("Failed to create game summary: %v", err)
+		}
+		return gameSummary
+	}
+
+	performance := func(t *testing.T) models.DealerPerformanceResponse {
+		w := authedRequest(admin.AccessToken, "GET", "/api/dealers/"+dealer.ID.String()+"/performance", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data models.DealerPerformanceResponse `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response.Data
+	}
+
+	start := time.Date(2003, 4, 1, 20, 0, 0, 0, time.UTC)
+
+	t.Run("RunningSessionIsNotScored", func(t *testing.T) {
+		createSession(models.GameSummaryStatusInProgress, start, time.Time{}, 0)
+
+		result := performance(t)
+		assert.Equal(t, int64(1), result.GamesDealt)
+		assert.Equal(t, float64(0), result.VoidRate)
+		assert.Equal(t, float32(0), result.Rating)
+	})
+
+	t.Run("ScoresPaceVoidsAndCorrections", func(t *testing.T) {
+		// An hour at full pace with four transactions, one of them corrected
+		completed := createSession(models.GameSummaryStatusCompleted, start, start.Add(time.Hour), models.TargetRoundsPerHour)
+		createSession(models.GameSummaryStatusVoided, start, start.Add(time.Hour), 0)
+
+		transactions := make([]models.Transaction, 4)
+		for i := range transactions {
+			transactions[i] = models.Transaction{ID: uuid.New(), GameSummaryID: completed.ID, PlayerID: player.ID, Amount: 10_00, Type: "win", Outcome: "win"}
+		}
+		transactions = append(transactions,
+			models.Transaction{ID: uuid.New(), GameSummaryID: completed.ID, PlayerID: player.ID, Amount: -10_00, Type: models.TransactionTypeReversal, Outcome: "loss", ReversesID: &transactions[0].ID, Reason: "Paid the wrong player"},
+			models.Transaction{ID: uuid.New(), GameSummaryID: completed.ID, PlayerID: player.ID, Amount: 5_00, Type: models.TransactionTypeCorrection, Outcome: "win", CorrectsID: &transactions[0].ID, Reason: "Paid the wrong player"},
+		)
+		if err := testDB.Create(&transactions).Error; err != nil {
+			t.Fatalf("Failed to create transactions: %v", err)
+		}
+
+		result := performance(t)
+		assert.Equal(t, int64(3), result.GamesDealt)
+		assert.Equal(t, float64(models.TargetRoundsPerHour), result.RoundsPerHour)
+		assert.Equal(t, 0.5, result.VoidRate)
+		assert.Equal(t, int64(1), result.Corrections)
+		assert.Equal(t, 0.25, result.ErrorRate)
+		assert.Equal(t, models.DealerRating(models.TargetRoundsPerHour, 0.5, 0.25), result.Rating)
+	})
+}
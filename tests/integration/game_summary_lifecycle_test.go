@@ -0,0 +1,99 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestGameSummaryLifecycle(t *testing.T) {
+	admin := signInAdmin(t)
+
+	suffix := time.Now().UnixNano()
+	game := models.Game{ID: uuid.New(), Name: fmt.Sprintf("Lifecycle Game %d", suffix), Type: "Blackjack", MaxPlayers: 7, MinPlayers: 1, MinBet: 1_00, MaxBet: 1000_00}
+	player := models.Player{ID: uuid.New(), Nickname: fmt.Sprintf("lifecycle-%d", suffix), Status: "active"}
+	scheduled := func() models.GameSummary {
+		return models.GameSummary{
+			ID:        uuid.New(),
+			GameID:    game.ID,
+			CasinoID:  admin.Casinos[0].ID,
+			DealerID:  admin.Dealer.ID,
+			Players:   []models.Player{player},
+			StartTime: time.Now().Add(time.Hour),
+			Status:    models.GameSummaryStatusScheduled,
+		}
+	}
+	played := scheduled()
+	voided := scheduled()
+	for _, record := range []interface{}{&game, &player, &played, &voided} {
+		if err := testDB.Create(record).Error; err != nil {
+			t.Fatalf("Failed to create %T: %v", record, err)
+		}
+	}
+
+	transition := func(t *testing.T, gameSummary models.GameSummary, action string, expectedStatus int) models.GameSummaryResponse {
+		w := authedRequest(admin.AccessToken, "POST", "/api/game-summaries/"+gameSummary.ID.String()+"/"+action, nil)
+		assert.Equal(t, expectedStatus, w.Code, "%s: %s", action, w.Body.String())
+
+		var response struct {
+			Data models.GameSummaryResponse `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response.Data
+	}
+
+	book := func(gameSummary models.GameSummary) int {
+		w := authedRequest(admin.AccessToken, "POST", "/api/transactions/", models.CreateTransactionRequest{
+			GameSummaryID: gameSummary.ID.String(), PlayerID: player.ID.String(), Amount: 10_00, Outcome: "win",
+		})
+		return w.Code
+	}
+
+	t.Run("StartPauseResumeClose", func(t *testing.T) {
+		transition(t, played, "resume", http.StatusConflict)
+
+		response := transition(t, played, "start", http.StatusOK)
+		assert.Equal(t, models.GameSummaryStatusInProgress, response.Status)
+		assert.Equal(t, http.StatusCreated, book(played))
+
+		response = transition(t, played, "pause", http.StatusOK)
+		assert.Equal(t, models.GameSummaryStatusPaused, response.Status)
+		transition(t, played, "pause", http.StatusConflict)
+
+		response = transition(t, played, "resume", http.StatusOK)
+		assert.Equal(t, models.GameSummaryStatusInProgress, response.Status)
+		assert.True(t, response.EndTime.IsZero())
+
+		closedAt := time.Now()
+		response = transition(t, played, "close", http.StatusOK)
+		assert.Equal(t, models.GameSummaryStatusCompleted, response.Status)
+		assert.WithinDuration(t, closedAt, response.EndTime, time.Minute)
+	})
+
+	t.Run("CompletedIsFinal", func(t *testing.T) {
+		transition(t, played, "resume", http.StatusConflict)
+		transition(t, played, "start", http.StatusConflict)
+		transition(t, played, "void", http.StatusConflict)
+		transition(t, played, "close", http.StatusConflict)
+
+		assert.Equal(t, http.StatusConflict, book(played))
+	})
+
+	t.Run("Void", func(t *testing.T) {
+		response := transition(t, voided, "void", http.StatusOK)
+		assert.Equal(t, models.GameSummaryStatusVoided, response.Status)
+
+		transition(t, voided, "start", http.StatusConflict)
+		assert.Equal(t, http.StatusConflict, book(voided))
+	})
+
+	t.Run("UnknownSession", func(t *testing.T) {
+		transition(t, models.GameSummary{ID: uuid.New()}, "start", http.StatusNotFound)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/suidevv/tableye-api/models"
 )
@@ -42,8 +43,8 @@ func TestGameSummaryController(t *testing.T) {
 			t.Fatal("Logged in user is not a dealer")
 		}
 
-		if signInResponse.Casino != nil {
-			casinoID = signInResponse.Casino.ID.String()
+		if len(signInResponse.Casinos) > 0 {
+			casinoID = signInResponse.Casinos[0].ID.String()
 		} else {
 			t.Fatal("Logged in user is not associated with a casino")
 		}
@@ -51,6 +52,8 @@ func TestGameSummaryController(t *testing.T) {
 
 	signInAndGetIDs()
 
+	ensureClockedIn(t, accessToken, uuid.MustParse(casinoID))
+
 	// Helper function to create a game and return its ID
 	createGame := func() string {
 		gamePayload := models.CreateGameRequest{
@@ -58,8 +61,8 @@ func TestGameSummaryController(t *testing.T) {
 			Type:       "Poker",
 			MaxPlayers: 8,
 			MinPlayers: 2,
-			MinBet:     10,
-			MaxBet:     1000,
+			MinBet:     10_00,
+			MaxBet:     1000_00,
 		}
 		jsonGamePayload, _ := json.Marshal(gamePayload)
 		w := httptest.NewRecorder()
@@ -71,7 +74,14 @@ func TestGameSummaryController(t *testing.T) {
 		var createGameResponse map[string]interface{}
 		json.Unmarshal(w.Body.Bytes(), &createGameResponse)
 		gameData := createGameResponse["data"].(map[string]interface{})
-		return gameData["id"].(string)
+		gameID := gameData["id"].(string)
+
+		// Sessions are only allowed for games the casino offers
+		if err := testDB.Exec("INSERT INTO casino_games (casino_id, game_id) VALUES (?, ?)", casinoID, gameID).Error; err != nil {
+			t.Fatalf("Failed to offer game at casino: %v", err)
+		}
+
+		return gameID
 	}
 
 	// Helper function to get player IDs
@@ -0,0 +1,123 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestGameSummaryTotals(t *testing.T) {
+	admin := signInAdmin(t)
+
+	suffix := time.Now().UnixNano()
+	game := models.Game{
+		ID:         uuid.New(),
+		Name:       fmt.Sprintf("Totals Game %d", suffix),
+		Type:       "Poker",
+		MaxPlayers: 8,
+		MinPlayers: 2,
+		MinBet:     1_00,
+		MaxBet:     1000_00,
+	}
+	alice := models.Player{ID: uuid.New(), Nickname: fmt.Sprintf("totals-alice-%d", suffix), Status: "active"}
+	bob := models.Player{ID: uuid.New(), Nickname: fmt.Sprintf("totals-bob-%d", suffix), Status: "active"}
+	gameSummary := models.GameSummary{
+		ID:        uuid.New(),
+		GameID:    game.ID,
+		CasinoID:  admin.Casinos[0].ID,
+		DealerID:  admin.Dealer.ID,
+		Players:   []models.Player{alice, bob},
+		StartTime: time.Now(),
+		Status:    models.GameSummaryStatusInProgress,
+	}
+	for _, record := range []interface{}{&game, &alice, &bob, &gameSummary} {
+		if err := testDB.Create(record).Error; err != nil {
+			t.Fatalf("Failed to create %T: %v", record, err)
+		}
+	}
+
+	book := func(player models.Player, amount models.Money, outcome string) models.TransactionResponse {
+		w := authedRequest(admin.AccessToken, "POST", "/api/transactions/", models.CreateTransactionRequest{
+			GameSummaryID: gameSummary.ID.String(), PlayerID: player.ID.String(), Amount: amount, Outcome: outcome,
+		})
+		if !assert.Equal(t, http.StatusCreated, w.Code, w.Body.String()) {
+			return models.TransactionResponse{}
+		}
+
+		var response struct {
+			Data models.TransactionResponse `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response.Data
+	}
+
+	assertTotals := func(t *testing.T, totalPot, highestBet models.Money, roundsPlayed int) {
+		var stored models.GameSummary
+		testDB.First(&stored, "id = ?", gameSummary.ID)
+		assert.Equal(t, totalPot, stored.TotalPot, "total pot")
+		assert.Equal(t, highestBet, stored.HighestBet, "highest bet")
+		assert.Equal(t, roundsPlayed, stored.RoundsPlayed, "rounds played")
+	}
+
+	var aliceWin, bobLoss models.TransactionResponse
+
+	t.Run("AfterCreate", func(t *testing.T) {
+		aliceWin = book(alice, 50_00, "win")
+		bobLoss = book(bob, -30_00, "loss")
+		book(alice, -20_00, "loss")
+
+		// Alice settled twice, so two rounds were played
+		assertTotals(t, 100_00, 50_00, 2)
+	})
+
+	t.Run("AfterDelete", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "DELETE", "/api/transactions/"+aliceWin.ID.String(), nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		assertTotals(t, 50_00, 30_00, 1)
+	})
+
+	t.Run("AfterCorrect", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "POST", "/api/transactions/"+bobLoss.ID.String()+"/corrections", models.CorrectTransactionRequest{Amount: -80_00, Outcome: "loss", Reason: "Misread the stack"})
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		// Only the corrected entry counts, not the original or its reversal
+		assertTotals(t, 100_00, 80_00, 1)
+	})
+
+	t.Run("RecordedRoundsWin", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "POST", "/api/game-summaries/"+gameSummary.ID.String()+"/rounds/", models.CreateRoundRequest{})
+		assert.Equal(t, http.StatusCreated, w.Code)
+		w = authedRequest(admin.AccessToken, "POST", "/api/game-summaries/"+gameSummary.ID.String()+"/rounds/", models.CreateRoundRequest{})
+		assert.Equal(t, http.StatusCreated, w.Code)
+		w = authedRequest(admin.AccessToken, "POST", "/api/game-summaries/"+gameSummary.ID.String()+"/rounds/", models.CreateRoundRequest{})
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		assertTotals(t, 100_00, 80_00, 3)
+	})
+
+	t.Run("ConcurrentBookings", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			player := alice
+			if i%2 == 1 {
+				player = bob
+			}
+			wg.Add(1)
+			go func(player models.Player) {
+				defer wg.Done()
+				book(player, 10_00, "win")
+			}(player)
+		}
+		wg.Wait()
+
+		assertTotals(t, 200_00, 80_00, 3)
+	})
+}
@@ -40,8 +40,8 @@ func TestGameController(t *testing.T) {
 			Type:       "Poker",
 			MaxPlayers: 8,
 			MinPlayers: 2,
-			MinBet:     10,
-			MaxBet:     1000,
+			MinBet:     10_00,
+			MaxBet:     1000_00,
 		}
 
 		jsonPayload, _ := json.Marshal(payload)
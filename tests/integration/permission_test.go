@@ -0,0 +1,56 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestPermissions(t *testing.T) {
+	myPermissions := func(accessToken string) []string {
+		w := authedRequest(accessToken, "GET", "/api/users/me/permissions", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data struct {
+				Permissions []string `json:"permissions"`
+			} `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response.Data.Permissions
+	}
+
+	dealer := signIn(t, "user12@example.com", "password12")
+	admin := signIn(t, "user13@example.com", "password13")
+
+	t.Run("MyPermissions", func(t *testing.T) {
+		assert.Contains(t, myPermissions(admin.AccessToken), models.PermissionPermissionsManage)
+
+		dealerPermissions := myPermissions(dealer.AccessToken)
+		assert.Contains(t, dealerPermissions, models.PermissionTransactionsCreate)
+		assert.NotContains(t, dealerPermissions, models.PermissionPlayersStats)
+	})
+
+	t.Run("GrantAndRevoke", func(t *testing.T) {
+		path := "/api/admin/roles/dealer/permissions/" + models.PermissionPlayersStats
+
+		w := authedRequest(dealer.AccessToken, "POST", path, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = authedRequest(admin.AccessToken, "POST", path, nil)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, myPermissions(dealer.AccessToken), models.PermissionPlayersStats)
+
+		w = authedRequest(admin.AccessToken, "DELETE", path, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.NotContains(t, myPermissions(dealer.AccessToken), models.PermissionPlayersStats)
+	})
+
+	t.Run("AdminKeepsPermissionManagement", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "DELETE", "/api/admin/roles/admin/permissions/"+models.PermissionPermissionsManage, nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
@@ -0,0 +1,102 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestPlayerStats(t *testing.T) {
+	admin := signInAdmin(t)
+
+	stats := func(t *testing.T, query string) models.PlayerStatsResponse {
+		w := authedRequest(admin.AccessToken, "GET", "/api/players/"+query, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data models.PlayerStatsResponse `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response.Data
+	}
+
+	// The player sat at the poker session but was never added to the roulette
+	// one, where they still placed a bet. The transactions were booked long
+	// after the sessions started.
+	suffix := time.Now().UnixNano()
+	poker := models.Game{ID: uuid.New(), Name: fmt.Sprintf("Stats Poker %d", suffix), Type: "Poker", MaxPlayers: 8, MinPlayers: 1, MinBet: 1_00, MaxBet: 1000_00}
+	roulette := models.Game{ID: uuid.New(), Name: fmt.Sprintf("Stats Roulette %d", suffix), Type: "Roulette", MaxPlayers: 8, MinPlayers: 1, MinBet: 1_00, MaxBet: 1000_00}
+	player := models.Player{ID: uuid.New(), Nickname: fmt.Sprintf("stats-%d", suffix), Status: "active"}
+	session := func(game models.Game, start time.Time, players []models.Player) models.GameSummary {
+		return models.GameSummary{
+			ID:        uuid.New(),
+			GameID:    game.ID,
+			CasinoID:  admin.Casinos[0].ID,
+			DealerID:  admin.Dealer.ID,
+			Players:   players,
+			StartTime: start,
+			Status:    models.GameSummaryStatusCompleted,
+		}
+	}
+	march := session(poker, time.Date(2001, 3, 10, 20, 0, 0, 0, time.UTC), []models.Player{player})
+	unlisted := session(roulette, time.Date(2001, 3, 20, 20, 0, 0, 0, time.UTC), nil)
+	may := session(poker, time.Date(2001, 5, 1, 20, 0, 0, 0, time.UTC), []models.Player{player})
+	transaction := func(gameSummary models.GameSummary, amount models.Money, outcome string) *models.Transaction {
+		return &models.Transaction{ID: uuid.New(), GameSummaryID: gameSummary.ID, PlayerID: player.ID, Amount: amount, Type: outcome, Outcome: outcome}
+	}
+	for _, record := range []interface{}{
+		&poker, &roulette, &player, &march, &unlisted, &may,
+		transaction(march, 40_00, "win"),
+		transaction(march, -10_00, "loss"),
+		transaction(unlisted, -25_00, "loss"),
+		transaction(may, 100_00, "win"),
+	} {
+		if err := testDB.Create(record).Error; err != nil {
+			t.Fatalf("Failed to create %T: %v", record, err)
+		}
+	}
+
+	sumGames := func(stats models.PlayerStatsResponse) (sessions, rounds int64, net models.Money) {
+		for _, game := range stats.Games {
+			sessions += game.SessionsPlayed
+			rounds += game.RoundsPlayed
+			net += game.NetResult
+		}
+		return sessions, rounds, net
+	}
+
+	t.Run("BreakdownAddsUp", func(t *testing.T) {
+		all := stats(t, player.ID.String()+"/stats")
+		assert.Equal(t, int64(3), all.SessionsPlayed)
+		assert.Equal(t, int64(4), all.RoundsPlayed)
+		assert.Equal(t, models.Money(105_00), all.NetResult)
+
+		sessions, rounds, net := sumGames(all)
+		assert.Equal(t, all.SessionsPlayed, sessions)
+		assert.Equal(t, all.RoundsPlayed, rounds)
+		assert.Equal(t, all.NetResult, net)
+	})
+
+	t.Run("FiltersOnSessionStart", func(t *testing.T) {
+		inMarch := stats(t, player.ID.String()+"/stats?from=2001-03-01&to=2001-03-31")
+		assert.Equal(t, int64(2), inMarch.SessionsPlayed)
+		assert.Equal(t, int64(3), inMarch.RoundsPlayed)
+		assert.Equal(t, int64(1), inMarch.Wins)
+		assert.Equal(t, int64(2), inMarch.Losses)
+		assert.Equal(t, models.Money(5_00), inMarch.NetResult)
+		assert.Equal(t, models.Money(40_00), inMarch.LargestBet)
+
+		if assert.Len(t, inMarch.Games, 2) {
+			sessions, rounds, net := sumGames(inMarch)
+			assert.Equal(t, inMarch.SessionsPlayed, sessions)
+			assert.Equal(t, inMarch.RoundsPlayed, rounds)
+			assert.Equal(t, inMarch.NetResult, net)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/controllers"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestPlayerWinnings(t *testing.T) {
+	admin := signInAdmin(t)
+
+	suffix := time.Now().UnixNano()
+	game := models.Game{ID: uuid.New(), Name: fmt.Sprintf("Winnings Game %d", suffix), Type: "Blackjack", MaxPlayers: 7, MinPlayers: 1, MinBet: 1_00, MaxBet: 1000_00}
+	player := models.Player{ID: uuid.New(), Nickname: fmt.Sprintf("winnings-%d", suffix), Status: "active"}
+	gameSummary := models.GameSummary{
+		ID:        uuid.New(),
+		GameID:    game.ID,
+		CasinoID:  admin.Casinos[0].ID,
+		DealerID:  admin.Dealer.ID,
+		Currency:  models.DefaultCurrency,
+		Players:   []models.Player{player},
+		StartTime: time.Now(),
+		Status:    models.GameSummaryStatusInProgress,
+	}
+	for _, record := range []interface{}{&game, &player, &gameSummary} {
+		if err := testDB.Create(record).Error; err != nil {
+			t.Fatalf("Failed to create %T: %v", record, err)
+		}
+	}
+
+	book := func(amount models.Money, outcome string) models.TransactionResponse {
+		w := authedRequest(admin.AccessToken, "POST", "/api/transactions/", models.CreateTransactionRequest{
+			GameSummaryID: gameSummary.ID.String(), PlayerID: player.ID.String(), Amount: amount, Outcome: outcome,
+		})
+		if !assert.Equal(t, http.StatusCreated, w.Code, w.Body.String()) {
+			return models.TransactionResponse{}
+		}
+
+		var response struct {
+			Data models.TransactionResponse `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response.Data
+	}
+
+	storedWinnings := func() models.Money {
+		var stored models.Player
+		testDB.Unscoped().First(&stored, "id = ?", player.ID)
+		return stored.TotalWinnings
+	}
+
+	findDiscrepancy := func(t *testing.T) *models.WinningsDiscrepancy {
+		discrepancies, err := controllers.FindWinningsDiscrepancies(testDB)
+		if err != nil {
+			t.Fatalf("Failed to compare player winnings: %v", err)
+		}
+		for _, d := range discrepancies {
+			if d.ID == player.ID {
+				return &d
+			}
+		}
+		return nil
+	}
+
+	t.Run("LedgerFollowsTransactions", func(t *testing.T) {
+		win := book(50_00, "win")
+		loss := book(-20_00, "loss")
+		assert.Equal(t, models.Money(30_00), storedWinnings())
+
+		w := authedRequest(admin.AccessToken, "POST", "/api/transactions/"+loss.ID.String()+"/corrections", models.CorrectTransactionRequest{Amount: -35_00, Outcome: "loss", Reason: "Misread the stack"})
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, models.Money(15_00), storedWinnings())
+
+		w = authedRequest(admin.AccessToken, "DELETE", "/api/transactions/"+win.ID.String(), nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, models.Money(-35_00), storedWinnings())
+
+		assert.Nil(t, findDiscrepancy(t))
+	})
+
+	t.Run("ReconcileReportsAndFixesDrift", func(t *testing.T) {
+		if err := testDB.Model(&models.Player{}).Where("id = ?", player.ID).Update("total_winnings", models.Money(999_00)).Error; err != nil {
+			t.Fatalf("Failed to make winnings drift: %v", err)
+		}
+
+		discrepancy := findDiscrepancy(t)
+		if assert.NotNil(t, discrepancy) {
+			assert.Equal(t, models.Money(999_00), discrepancy.TotalWinnings)
+			assert.Equal(t, models.Money(-35_00), discrepancy.LedgerTotal)
+		}
+
+		corrected, err := controllers.ReconcilePlayerWinnings(testDB)
+		if err != nil {
+			t.Fatalf("Failed to fix player winnings: %v", err)
+		}
+		assert.GreaterOrEqual(t, corrected, int64(1))
+		assert.Equal(t, models.Money(-35_00), storedWinnings())
+		assert.Nil(t, findDiscrepancy(t))
+	})
+}
@@ -0,0 +1,132 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestReports(t *testing.T) {
+	admin := signInAdmin(t)
+
+	report := func(t *testing.T, path string, rows interface{}) {
+		w := authedRequest(admin.AccessToken, "GET", path, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		json.Unmarshal(w.Body.Bytes(), &struct {
+			Data interface{} `json:"data"`
+		}{Data: rows})
+	}
+
+	// One casino with a session at a table, one without a table and one voided
+	// session, all in 2002
+	suffix := time.Now().UnixNano()
+	casino := models.Casino{
+		ID:            uuid.New(),
+		Name:          fmt.Sprintf("Report Casino %d", suffix),
+		Location:      "Monaco",
+		LicenseNumber: fmt.Sprintf("RPT-%d", suffix),
+		MaxCapacity:   100,
+		Status:        "active",
+	}
+	game := models.Game{ID: uuid.New(), Name: fmt.Sprintf("Report Game %d", suffix), Type: "Blackjack", MaxPlayers: 7, MinPlayers: 1, MinBet: 1_00, MaxBet: 1000_00}
+	table := models.Table{ID: uuid.New(), CasinoID: casino.ID, TableNumber: "R1", GameID: game.ID, Status: models.TableStatusOpen}
+	player := models.Player{ID: uuid.New(), Nickname: fmt.Sprintf("report-%d", suffix), Status: "active"}
+	session := func(start time.Time, tableID *uuid.UUID, status string) models.GameSummary {
+		return models.GameSummary{
+			ID:        uuid.New(),
+			GameID:    game.ID,
+			CasinoID:  casino.ID,
+			DealerID:  admin.Dealer.ID,
+			TableID:   tableID,
+			Players:   []models.Player{player},
+			StartTime: start,
+			Status:    status,
+		}
+	}
+	atTable := session(time.Date(2002, 1, 10, 20, 0, 0, 0, time.UTC), &table.ID, models.GameSummaryStatusCompleted)
+	offTable := session(time.Date(2002, 1, 20, 20, 0, 0, 0, time.UTC), nil, models.GameSummaryStatusCompleted)
+	voided := session(time.Date(2002, 2, 5, 20, 0, 0, 0, time.UTC), nil, models.GameSummaryStatusVoided)
+	transaction := func(gameSummary models.GameSummary, amount models.Money, outcome string) *models.Transaction {
+		return &models.Transaction{ID: uuid.New(), GameSummaryID: gameSummary.ID, PlayerID: player.ID, Amount: amount, Type: outcome, Outcome: outcome}
+	}
+	reversed := transaction(atTable, -20_00, "loss")
+	reversal := transaction(atTable, 20_00, "win")
+	reversal.ReversesID = &reversed.ID
+	for _, record := range []interface{}{
+		&casino, &game, &table, &player, &atTable, &offTable, &voided,
+		transaction(atTable, 30_00, "win"),
+		transaction(atTable, -50_00, "loss"),
+		reversed,
+		reversal,
+		transaction(offTable, -100_00, "loss"),
+		transaction(voided, -500_00, "loss"),
+	} {
+		if err := testDB.Create(record).Error; err != nil {
+			t.Fatalf("Failed to create %T: %v", record, err)
+		}
+	}
+
+	filter := "casino_id=" + casino.ID.String()
+
+	t.Run("Revenue", func(t *testing.T) {
+		var rows []models.CasinoRevenueReport
+		report(t, "/api/reports/revenue?period=month&"+filter, &rows)
+		if assert.Len(t, rows, 1) {
+			assert.Equal(t, time.Date(2002, 1, 1, 0, 0, 0, 0, time.UTC), rows[0].PeriodStart.UTC())
+			assert.Equal(t, int64(2), rows[0].Sessions)
+			// The reversed pair and the voided session are left out
+			assert.Equal(t, models.Money(180_00), rows[0].SettledVolume)
+			assert.Equal(t, models.Money(120_00), rows[0].GrossGamingRevenue)
+		}
+	})
+
+	t.Run("RevenueFiltersOnSessionStart", func(t *testing.T) {
+		var rows []models.CasinoRevenueReport
+		report(t, "/api/reports/revenue?from=2002-01-15&to=2002-01-31&"+filter, &rows)
+		if assert.Len(t, rows, 1) {
+			assert.Equal(t, int64(1), rows[0].Sessions)
+			assert.Equal(t, models.Money(100_00), rows[0].SettledVolume)
+			assert.Equal(t, models.Money(100_00), rows[0].GrossGamingRevenue)
+		}
+	})
+
+	t.Run("Games", func(t *testing.T) {
+		var rows []models.GameResultReport
+		report(t, "/api/reports/games?"+filter, &rows)
+		if assert.Len(t, rows, 1) {
+			assert.Equal(t, game.ID, rows[0].GameID)
+			assert.Equal(t, models.Money(180_00), rows[0].SettledVolume)
+			assert.Equal(t, models.Money(120_00), rows[0].HouseWin)
+			assert.InDelta(t, 66.67, rows[0].WinPercentage, 0.01)
+		}
+	})
+
+	t.Run("Dealers", func(t *testing.T) {
+		var rows []models.DealerTableReport
+		report(t, "/api/reports/dealers?"+filter, &rows)
+		if assert.Len(t, rows, 1) {
+			assert.Equal(t, admin.Dealer.ID, rows[0].DealerID)
+			assert.Equal(t, int64(2), rows[0].Sessions)
+			assert.Equal(t, models.Money(180_00), rows[0].SettledVolume)
+			assert.Equal(t, models.Money(120_00), rows[0].HouseWin)
+		}
+	})
+
+	t.Run("Tables", func(t *testing.T) {
+		var rows []models.TableReport
+		report(t, "/api/reports/tables?"+filter, &rows)
+		if assert.Len(t, rows, 1) {
+			assert.Equal(t, table.ID, rows[0].TableID)
+			assert.Equal(t, int64(1), rows[0].Sessions)
+			assert.Equal(t, models.Money(80_00), rows[0].SettledVolume)
+			assert.Equal(t, models.Money(20_00), rows[0].HouseWin)
+		}
+	})
+}
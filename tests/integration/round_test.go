@@ -0,0 +1,103 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestRounds(t *testing.T) {
+	admin := signInAdmin(t)
+
+	casinoID := admin.Casinos[0].ID
+	suffix := time.Now().UnixNano()
+	newDealer := func(name string) (models.User, models.Dealer) {
+		user := models.User{ID: uuid.New(), Name: name, Email: fmt.Sprintf("%s%d@example.com", name, suffix), Password: "x", Provider: "local", Verified: true}
+		dealer := models.Dealer{ID: uuid.New(), UserID: user.ID, DealerCode: fmt.Sprintf("%s%d", name[:1], suffix), Status: "active"}
+		return user, dealer
+	}
+	reliefUser, relief := newDealer("relief")
+	strangerUser, stranger := newDealer("stranger")
+	game := models.Game{ID: uuid.New(), Name: fmt.Sprintf("Rounds Game %d", suffix), Type: "Roulette", MaxPlayers: 8, MinPlayers: 1, MinBet: 1_00, MaxBet: 1000_00}
+	gameSummary := models.GameSummary{
+		ID:        uuid.New(),
+		GameID:    game.ID,
+		CasinoID:  casinoID,
+		DealerID:  admin.Dealer.ID,
+		StartTime: time.Now(),
+		Status:    models.GameSummaryStatusInProgress,
+	}
+	now := time.Now()
+	shift := models.Shift{ID: uuid.New(), DealerID: relief.ID, CasinoID: casinoID, ClockInAt: &now, Status: models.ShiftStatusActive}
+	for _, record := range []interface{}{&reliefUser, &relief, &strangerUser, &stranger, &game, &gameSummary} {
+		if err := testDB.Create(record).Error; err != nil {
+			t.Fatalf("Failed to create %T: %v", record, err)
+		}
+	}
+	if err := testDB.Exec("INSERT INTO casino_dealers (casino_id, dealer_id) VALUES (?, ?)", casinoID, relief.ID).Error; err != nil {
+		t.Fatalf("Failed to assign relief dealer: %v", err)
+	}
+
+	path := "/api/game-summaries/" + gameSummary.ID.String() + "/rounds/"
+
+	t.Run("NumbersRoundsInOrder", func(t *testing.T) {
+		var rounds [2]models.RoundResponse
+		for i := range rounds {
+			w := authedRequest(admin.AccessToken, "POST", path, models.CreateRoundRequest{})
+			assert.Equal(t, http.StatusCreated, w.Code)
+
+			var response struct {
+				Data models.RoundResponse `json:"data"`
+			}
+			json.Unmarshal(w.Body.Bytes(), &response)
+			rounds[i] = response.Data
+		}
+		assert.Equal(t, 1, rounds[0].RoundNumber)
+		assert.Equal(t, 2, rounds[1].RoundNumber)
+		assert.Equal(t, admin.Dealer.ID, rounds[0].DealerID)
+	})
+
+	t.Run("DuplicateNumberConflicts", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "POST", path, models.CreateRoundRequest{RoundNumber: 2})
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		var count int64
+		testDB.Model(&models.Round{}).Where("game_summary_id = ?", gameSummary.ID).Count(&count)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("DealerNotAssignedToCasino", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "POST", path, models.CreateRoundRequest{DealerID: stranger.ID.String()})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = authedRequest(admin.AccessToken, "POST", path, models.CreateRoundRequest{DealerID: uuid.New().String()})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("DealerNotClockedIn", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "POST", path, models.CreateRoundRequest{DealerID: relief.ID.String()})
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("ReliefDealer", func(t *testing.T) {
+		if err := testDB.Create(&shift).Error; err != nil {
+			t.Fatalf("Failed to clock in relief dealer: %v", err)
+		}
+
+		w := authedRequest(admin.AccessToken, "POST", path, models.CreateRoundRequest{DealerID: relief.ID.String()})
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var response struct {
+			Data models.RoundResponse `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, 3, response.Data.RoundNumber)
+		assert.Equal(t, relief.ID, response.Data.DealerID)
+	})
+}
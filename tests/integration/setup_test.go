@@ -1,12 +1,19 @@
 package integration
 
 import (
+	"bytes"
+	"encoding/json"
 	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/suidevv/tableye-api/controllers"
 	"github.com/suidevv/tableye-api/initializers"
 	"github.com/suidevv/tableye-api/middleware"
+	"github.com/suidevv/tableye-api/models"
 	"gorm.io/gorm"
 )
 
@@ -28,23 +35,31 @@ func init() {
 		log.Fatal("Failed to connect to the test database")
 	}
 
+	initializers.LoadTokenService(&config)
+
 	// Set up the test router
 	gin.SetMode(gin.TestMode)
 	testRouter = gin.Default()
+	testRouter.Use(middleware.RequestID())
 	setupTestRoutes(testRouter, testDB)
 }
 
 func setupTestRoutes(router *gin.Engine, db *gorm.DB) {
 	// Initialize controllers
-	authController := controllers.NewAuthController(db)
+	authController := controllers.NewAuthController(db, initializers.Tokens)
 	userController := controllers.NewUserController(db)
 	casinoController := controllers.NewCasinoController(db)
+	tableController := controllers.NewTableController(db)
 	gameController := controllers.NewGameController(db)
 	playerController := controllers.NewPlayerController(db)
 	dealerController := controllers.NewDealerController(db)
+	shiftController := controllers.NewShiftController(db)
 	gameSummaryController := controllers.NewGameSummaryController(db)
+	roundController := controllers.NewRoundController(db)
 	transactionController := controllers.NewTransactionController(db)
+	reportController := controllers.NewReportController(db)
 	adminController := controllers.NewAdminController(db)
+	permissionController := controllers.NewPermissionController(db)
 
 	// Setup routes
 	api := router.Group("/api")
@@ -54,89 +69,173 @@ func setupTestRoutes(router *gin.Engine, db *gorm.DB) {
 	{
 		auth.POST("/register", authController.SignUpUser)
 		auth.POST("/login", authController.SignInUser)
-		auth.GET("/refresh", authController.RefreshAccessToken)
-		auth.GET("/logout", middleware.DeserializeUser(), authController.LogoutUser)
+		auth.POST("/verify-email", authController.VerifyEmail)
+		auth.POST("/resend-verification", authController.ResendVerification)
+		auth.POST("/forgot-password", authController.ForgotPassword)
+		auth.POST("/reset-password", authController.ResetPassword)
+		auth.POST("/refresh", authController.RefreshAccessToken)
+		auth.GET("/logout", middleware.DeserializeUserForEnrolment(), authController.LogoutUser)
+		auth.GET("/sessions", middleware.DeserializeUser(), authController.FindSessions)
+		auth.DELETE("/sessions/:sessionId", middleware.DeserializeUser(), authController.RevokeSession)
+		auth.POST("/2fa/verify", authController.VerifyTwoFactor)
+		auth.POST("/2fa/setup", middleware.DeserializeUserForEnrolment(), authController.SetupTwoFactor)
+		auth.POST("/2fa/enable", middleware.DeserializeUserForEnrolment(), authController.EnableTwoFactor)
+		auth.POST("/2fa/disable", middleware.DeserializeUser(), authController.DisableTwoFactor)
+		auth.POST("/2fa/recovery-codes", middleware.DeserializeUser(), authController.RegenerateRecoveryCodes)
 	}
 
 	// User routes
 	users := api.Group("/users")
 	users.Use(middleware.DeserializeUser())
 	{
-		users.GET("/me", middleware.AuthorizeRoles("admin"), userController.GetMe)
+		users.GET("/me", middleware.RequirePermission(models.PermissionProfileRead), userController.GetMe)
+		users.GET("/me/permissions", userController.GetMyPermissions)
 	}
 
 	// Casino routes
 	casinos := api.Group("/casinos")
-	casinos.Use(middleware.DeserializeUser())
+	casinos.Use(middleware.DeserializeUser(), middleware.ScopeCasinos())
 	{
-		casinos.POST("/", middleware.AuthorizeRoles("admin"), casinoController.CreateCasino)
-		casinos.GET("/", middleware.AuthorizeRoles("admin", "dealer"), casinoController.FindCasinos)
-		casinos.GET("/:casinoId", middleware.AuthorizeRoles("admin", "dealer"), casinoController.FindCasinoById)
-		casinos.PUT("/:casinoId", middleware.AuthorizeRoles("admin"), casinoController.UpdateCasino)
-		casinos.DELETE("/:casinoId", middleware.AuthorizeRoles("admin"), casinoController.DeleteCasino)
+		casinos.POST("/", middleware.RequirePermission(models.PermissionCasinosCreate), casinoController.CreateCasino)
+		casinos.GET("/", middleware.RequirePermission(models.PermissionCasinosRead), casinoController.FindCasinos)
+		casinos.GET("/:casinoId", middleware.RequirePermission(models.PermissionCasinosRead), casinoController.FindCasinoById)
+		casinos.PUT("/:casinoId", middleware.RequirePermission(models.PermissionCasinosUpdate), casinoController.UpdateCasino)
+		casinos.DELETE("/:casinoId", middleware.RequirePermission(models.PermissionCasinosDelete), casinoController.DeleteCasino)
+		casinos.GET("/:casinoId/games", middleware.RequirePermission(models.PermissionCasinosRead), casinoController.FindCasinoGames)
+		casinos.POST("/:casinoId/games/:gameId", middleware.RequirePermission(models.PermissionCasinoGamesManage), casinoController.AddCasinoGame)
+		casinos.DELETE("/:casinoId/games/:gameId", middleware.RequirePermission(models.PermissionCasinoGamesManage), casinoController.RemoveCasinoGame)
+		casinos.GET("/:casinoId/dealers", middleware.RequirePermission(models.PermissionCasinosRead), casinoController.FindCasinoDealers)
+		casinos.POST("/:casinoId/dealers/:dealerId", middleware.RequirePermission(models.PermissionCasinoDealersManage), casinoController.AddCasinoDealer)
+		casinos.DELETE("/:casinoId/dealers/:dealerId", middleware.RequirePermission(models.PermissionCasinoDealersManage), casinoController.RemoveCasinoDealer)
+		casinos.POST("/:casinoId/tables", middleware.RequirePermission(models.PermissionTablesWrite), tableController.CreateTable)
+		casinos.GET("/:casinoId/tables", middleware.RequirePermission(models.PermissionTablesRead), tableController.FindTables)
+		casinos.GET("/:casinoId/tables/:tableId", middleware.RequirePermission(models.PermissionTablesRead), tableController.FindTableById)
+		casinos.PUT("/:casinoId/tables/:tableId", middleware.RequirePermission(models.PermissionTablesWrite), tableController.UpdateTable)
+		casinos.DELETE("/:casinoId/tables/:tableId", middleware.RequirePermission(models.PermissionTablesWrite), tableController.DeleteTable)
 	}
 
 	// Game routes
 	games := api.Group("/games")
-	games.Use(middleware.DeserializeUser())
+	games.Use(middleware.DeserializeUser(), middleware.ScopeCasinos())
 	{
-		games.POST("/", middleware.AuthorizeRoles("admin"), gameController.CreateGame)
-		games.GET("/", middleware.AuthorizeRoles("admin", "dealer"), gameController.FindGames)
-		games.GET("/:gameId", middleware.AuthorizeRoles("admin", "dealer"), gameController.FindGameById)
-		games.PUT("/:gameId", middleware.AuthorizeRoles("admin"), gameController.UpdateGame)
-		games.DELETE("/:gameId", middleware.AuthorizeRoles("admin"), gameController.DeleteGame)
+		games.POST("/", middleware.RequirePermission(models.PermissionGamesWrite), gameController.CreateGame)
+		games.GET("/", middleware.RequirePermission(models.PermissionGamesRead), gameController.FindGames)
+		games.GET("/:gameId", middleware.RequirePermission(models.PermissionGamesRead), gameController.FindGameById)
+		games.PUT("/:gameId", middleware.RequirePermission(models.PermissionGamesWrite), gameController.UpdateGame)
+		games.DELETE("/:gameId", middleware.RequirePermission(models.PermissionGamesWrite), gameController.DeleteGame)
+		games.GET("/:gameId/casinos", middleware.RequirePermission(models.PermissionGamesRead), gameController.FindGameCasinos)
 	}
 
 	// Player routes
 	players := api.Group("/players")
 	players.Use(middleware.DeserializeUser())
 	{
-		players.POST("/", middleware.AuthorizeRoles("admin"), playerController.CreatePlayer)
-		players.GET("/", middleware.AuthorizeRoles("admin", "dealer"), playerController.FindPlayers)
-		players.GET("/:playerId", middleware.AuthorizeRoles("admin", "dealer"), playerController.FindPlayerById)
-		players.PUT("/:playerId", middleware.AuthorizeRoles("admin"), playerController.UpdatePlayer)
-		players.DELETE("/:playerId", middleware.AuthorizeRoles("admin"), playerController.DeletePlayer)
-		players.GET("/:playerId/stats", middleware.AuthorizeRoles("admin"), playerController.FindPlayerStats)
+		players.POST("/", middleware.RequirePermission(models.PermissionPlayersWrite), playerController.CreatePlayer)
+		players.GET("/", middleware.RequirePermission(models.PermissionPlayersRead), playerController.FindPlayers)
+		players.GET("/:playerId", middleware.RequirePermission(models.PermissionPlayersRead), playerController.FindPlayerById)
+		players.PUT("/:playerId", middleware.RequirePermission(models.PermissionPlayersWrite), playerController.UpdatePlayer)
+		players.DELETE("/:playerId", middleware.RequirePermission(models.PermissionPlayersWrite), playerController.DeletePlayer)
+		players.GET("/:playerId/stats", middleware.RequirePermission(models.PermissionPlayersStats), playerController.FindPlayerStats)
 	}
 
 	// Dealer routes
 	dealers := api.Group("/dealers")
-	dealers.Use(middleware.DeserializeUser())
+	dealers.Use(middleware.DeserializeUser(), middleware.ScopeCasinos())
+	{
+		dealers.POST("/", middleware.RequirePermission(models.PermissionDealersWrite), dealerController.CreateDealer)
+		dealers.GET("/", middleware.RequirePermission(models.PermissionDealersRead), dealerController.FindDealers)
+		dealers.GET("/:dealerId", middleware.RequirePermission(models.PermissionDealersRead), dealerController.FindDealerById)
+		dealers.PUT("/:dealerId", middleware.RequirePermission(models.PermissionDealersWrite), dealerController.UpdateDealer)
+		dealers.DELETE("/:dealerId", middleware.RequirePermission(models.PermissionDealersWrite), dealerController.DeleteDealer)
+		dealers.GET("/:dealerId/performance", middleware.RequirePermission(models.PermissionDealersRead), dealerController.FindDealerPerformance)
+		dealers.GET("/:dealerId/casinos", middleware.RequirePermission(models.PermissionDealersRead), dealerController.FindDealerCasinos)
+	}
+
+	// Shift routes
+	shifts := api.Group("/shifts")
+	shifts.Use(middleware.DeserializeUser(), middleware.ScopeCasinos())
 	{
-		dealers.POST("/", middleware.AuthorizeRoles("admin"), dealerController.CreateDealer)
-		dealers.GET("/", middleware.AuthorizeRoles("admin", "dealer"), dealerController.FindDealers)
-		dealers.GET("/:dealerId", middleware.AuthorizeRoles("admin", "dealer"), dealerController.FindDealerById)
-		dealers.PUT("/:dealerId", middleware.AuthorizeRoles("admin"), dealerController.UpdateDealer)
-		dealers.DELETE("/:dealerId", middleware.AuthorizeRoles("admin"), dealerController.DeleteDealer)
+		shifts.POST("/", middleware.RequirePermission(models.PermissionShiftsPlan), shiftController.CreateShift)
+		shifts.GET("/", middleware.RequirePermission(models.PermissionShiftsRead), shiftController.FindShifts)
+		shifts.DELETE("/:shiftId", middleware.RequirePermission(models.PermissionShiftsPlan), shiftController.DeleteShift)
+		shifts.GET("/current", middleware.RequirePermission(models.PermissionShiftsClock), shiftController.FindCurrentShift)
+		shifts.POST("/clock-in", middleware.RequirePermission(models.PermissionShiftsClock), shiftController.ClockIn)
+		shifts.POST("/clock-out", middleware.RequirePermission(models.PermissionShiftsClock), shiftController.ClockOut)
+		shifts.POST("/breaks/start", middleware.RequirePermission(models.PermissionShiftsClock), shiftController.StartBreak)
+		shifts.POST("/breaks/end", middleware.RequirePermission(models.PermissionShiftsClock), shiftController.EndBreak)
 	}
 
 	// Game Summary routes
 	gameSummaries := api.Group("/game-summaries")
-	gameSummaries.Use(middleware.DeserializeUser())
+	gameSummaries.Use(middleware.DeserializeUser(), middleware.ScopeCasinos())
 	{
-		gameSummaries.POST("/", middleware.AuthorizeRoles("admin", "dealer"), gameSummaryController.CreateGameSummary)
-		gameSummaries.GET("/", middleware.AuthorizeRoles("admin"), gameSummaryController.FindGameSummaries)
-		gameSummaries.GET("/:gameSummaryId", middleware.AuthorizeRoles("admin"), gameSummaryController.FindGameSummaryById)
-		gameSummaries.PUT("/:gameSummaryId", middleware.AuthorizeRoles("admin", "dealer"), gameSummaryController.UpdateGameSummary)
-		gameSummaries.DELETE("/:gameSummaryId", middleware.AuthorizeRoles("admin"), gameSummaryController.DeleteGameSummary)
+		gameSummaries.POST("/", middleware.RequirePermission(models.PermissionGameSummariesCreate), gameSummaryController.CreateGameSummary)
+		gameSummaries.GET("/", middleware.RequirePermission(models.PermissionGameSummariesRead), gameSummaryController.FindGameSummaries)
+		gameSummaries.GET("/:gameSummaryId", middleware.RequirePermission(models.PermissionGameSummariesRead), gameSummaryController.FindGameSummaryById)
+		gameSummaries.PUT("/:gameSummaryId", middleware.RequirePermission(models.PermissionGameSummariesUpdate), gameSummaryController.UpdateGameSummary)
+		gameSummaries.DELETE("/:gameSummaryId", middleware.RequirePermission(models.PermissionGameSummariesDelete), gameSummaryController.DeleteGameSummary)
+		gameSummaries.POST("/:gameSummaryId/start", middleware.RequirePermission(models.PermissionGameSummariesOperate), gameSummaryController.StartGameSummary)
+		gameSummaries.POST("/:gameSummaryId/pause", middleware.RequirePermission(models.PermissionGameSummariesOperate), gameSummaryController.PauseGameSummary)
+		gameSummaries.POST("/:gameSummaryId/resume", middleware.RequirePermission(models.PermissionGameSummariesOperate), gameSummaryController.ResumeGameSummary)
+		gameSummaries.POST("/:gameSummaryId/close", middleware.RequirePermission(models.PermissionGameSummariesOperate), gameSummaryController.CloseGameSummary)
+		gameSummaries.POST("/:gameSummaryId/void", middleware.RequirePermission(models.PermissionGameSummariesVoid), gameSummaryController.VoidGameSummary)
+	}
+
+	// Round routes
+	rounds := api.Group("/game-summaries/:gameSummaryId/rounds")
+	rounds.Use(middleware.DeserializeUser(), middleware.ScopeCasinos())
+	{
+		rounds.POST("/", middleware.RequirePermission(models.PermissionRoundsWrite), roundController.CreateRound)
+		rounds.GET("/", middleware.RequirePermission(models.PermissionRoundsRead), roundController.FindRounds)
+		rounds.PUT("/:roundId", middleware.RequirePermission(models.PermissionRoundsWrite), roundController.UpdateRound)
 	}
 
 	// Transaction routes
 	transactions := api.Group("/transactions")
-	transactions.Use(middleware.DeserializeUser())
+	transactions.Use(middleware.DeserializeUser(), middleware.ScopeCasinos())
 	{
-		transactions.POST("/", middleware.AuthorizeRoles("admin", "dealer"), transactionController.CreateTransaction)
-		transactions.GET("/", middleware.AuthorizeRoles("admin", "dealer"), transactionController.FindTransactions)
-		transactions.GET("/:transactionId", middleware.AuthorizeRoles("admin", "dealer"), transactionController.FindTransactionById)
-		transactions.PUT("/:transactionId", middleware.AuthorizeRoles("admin"), transactionController.UpdateTransaction)
-		transactions.DELETE("/:transactionId", middleware.AuthorizeRoles("admin"), transactionController.DeleteTransaction)
+		transactions.POST("/", middleware.RequirePermission(models.PermissionTransactionsCreate), transactionController.CreateTransaction)
+		transactions.GET("/", middleware.RequirePermission(models.PermissionTransactionsRead), transactionController.FindTransactions)
+		transactions.GET("/:transactionId", middleware.RequirePermission(models.PermissionTransactionsRead), transactionController.FindTransactionById)
+		transactions.POST("/:transactionId/corrections", middleware.RequirePermission(models.PermissionTransactionsUpdate), transactionController.CorrectTransaction)
+		transactions.DELETE("/:transactionId", middleware.RequirePermission(models.PermissionTransactionsDelete), transactionController.DeleteTransaction)
+	}
+
+	// Report routes
+	reports := api.Group("/reports")
+	reports.Use(middleware.DeserializeUser(), middleware.RequirePermission(models.PermissionReportsRead), middleware.ScopeCasinos())
+	{
+		reports.GET("/revenue", reportController.CasinoRevenue)
+		reports.GET("/games", reportController.GameResults)
+		reports.GET("/dealers", reportController.DealerTables)
+		reports.GET("/tables", reportController.TableResults)
 	}
 
 	// Admin routes
 	admin := api.Group("/admin")
-	admin.Use(middleware.DeserializeUser(), middleware.AuthorizeRoles("admin"))
+	admin.Use(middleware.DeserializeUser())
 	{
-		admin.POST("/assign-admin", adminController.AssignAdminRole)
+		admin.POST("/assign-admin", middleware.RequirePermission(models.PermissionRolesAssign), adminController.AssignAdminRole)
+		admin.POST("/game-summaries/recalculate-totals", middleware.RequirePermission(models.PermissionGameSummariesRecalc), adminController.RecalculateGameSummaryTotals)
+		admin.GET("/casinos/:casinoId/owners", middleware.RequirePermission(models.PermissionRolesAssign), adminController.FindCasinoOwners)
+		admin.POST("/casinos/:casinoId/owners/:userId", middleware.RequirePermission(models.PermissionRolesAssign), adminController.GrantCasinoOwnership)
+		admin.DELETE("/casinos/:casinoId/owners/:userId", middleware.RequirePermission(models.PermissionRolesAssign), adminController.RevokeCasinoOwnership)
+		admin.POST("/users/:userId/unlock", middleware.RequirePermission(models.PermissionAccountsUnlock), adminController.UnlockAccount)
+		admin.GET("/security-events", middleware.RequirePermission(models.PermissionSecurityEventsRead), adminController.FindSecurityEvents)
+		admin.GET("/audit", middleware.RequirePermission(models.PermissionAuditRead), adminController.FindAuditEvents)
+		admin.GET("/audit/verify", middleware.RequirePermission(models.PermissionAuditRead), adminController.VerifyAuditChain)
+		admin.GET("/exchange-rates", middleware.RequirePermission(models.PermissionExchangeRatesManage), adminController.FindExchangeRates)
+		admin.PUT("/exchange-rates", middleware.RequirePermission(models.PermissionExchangeRatesManage), adminController.SetExchangeRate)
+		admin.GET("/deleted/game-summaries", middleware.RequirePermission(models.PermissionDeletedRecordsManage), middleware.ScopeCasinos(), gameSummaryController.FindDeletedGameSummaries)
+		admin.POST("/deleted/game-summaries/:gameSummaryId/restore", middleware.RequirePermission(models.PermissionDeletedRecordsManage), middleware.ScopeCasinos(), gameSummaryController.RestoreGameSummary)
+		admin.GET("/deleted/transactions", middleware.RequirePermission(models.PermissionDeletedRecordsManage), middleware.ScopeCasinos(), transactionController.FindDeletedTransactions)
+		admin.POST("/deleted/transactions/:transactionId/restore", middleware.RequirePermission(models.PermissionDeletedRecordsManage), middleware.ScopeCasinos(), transactionController.RestoreTransaction)
+		admin.GET("/deleted/players", middleware.RequirePermission(models.PermissionDeletedRecordsManage), playerController.FindDeletedPlayers)
+		admin.POST("/deleted/players/:playerId/restore", middleware.RequirePermission(models.PermissionDeletedRecordsManage), playerController.RestorePlayer)
+		admin.GET("/permissions", middleware.RequirePermission(models.PermissionPermissionsManage), permissionController.FindPermissions)
+		admin.GET("/roles/:role/permissions", middleware.RequirePermission(models.PermissionPermissionsManage), permissionController.FindRolePermissions)
+		admin.POST("/roles/:role/permissions/:permission", middleware.RequirePermission(models.PermissionPermissionsManage), permissionController.GrantRolePermission)
+		admin.DELETE("/roles/:role/permissions/:permission", middleware.RequirePermission(models.PermissionPermissionsManage), permissionController.RevokeRolePermission)
 	}
 }
 
@@ -147,3 +246,55 @@ func GetTestDB() *gorm.DB {
 func GetTestRouter() *gin.Engine {
 	return testRouter
 }
+
+// signIn signs a seeded user in and returns their tokens, dealer profile and
+// casinos.
+func signIn(t *testing.T, email, password string) models.SignInResponse {
+	t.Helper()
+	jsonSignInPayload, _ := json.Marshal(models.SignInInput{Email: email, Password: password})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/auth/login", bytes.NewBuffer(jsonSignInPayload))
+	req.Header.Set("Content-Type", "application/json")
+	testRouter.ServeHTTP(w, req)
+
+	var signInResponse models.SignInResponse
+	json.Unmarshal(w.Body.Bytes(), &signInResponse)
+	return signInResponse
+}
+
+// signInAdmin signs in seeded user13, an admin with a dealer profile whose
+// first casino the tests work in.
+func signInAdmin(t *testing.T) models.SignInResponse {
+	t.Helper()
+	admin := signIn(t, "user13@example.com", "password13")
+	if admin.Dealer == nil || len(admin.Casinos) == 0 {
+		t.Fatal("Seeded admin user has no dealer profile or casino")
+	}
+	return admin
+}
+
+// authedRequest sends payload as JSON with the access token. A nil payload
+// sends an empty body.
+func authedRequest(accessToken, method, path string, payload interface{}) *httptest.ResponseRecorder {
+	body := bytes.NewBuffer(nil)
+	if payload != nil {
+		jsonPayload, _ := json.Marshal(payload)
+		body = bytes.NewBuffer(jsonPayload)
+	}
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	testRouter.ServeHTTP(w, req)
+	return w
+}
+
+// ensureClockedIn clocks the signed-in dealer in at the casino, which opening
+// a session requires. A 409 means an earlier run left them clocked in.
+func ensureClockedIn(t *testing.T, accessToken string, casinoID uuid.UUID) {
+	t.Helper()
+	w := authedRequest(accessToken, "POST", "/api/shifts/clock-in", models.ClockInRequest{CasinoID: casinoID.String()})
+	if w.Code != http.StatusOK && w.Code != http.StatusConflict {
+		t.Fatalf("Failed to clock in: %d %s", w.Code, w.Body.String())
+	}
+}
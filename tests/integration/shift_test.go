@@ -0,0 +1,177 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+	"github.com/suidevv/tableye-api/utils"
+)
+
+func TestShifts(t *testing.T) {
+	admin := signInAdmin(t)
+	casinoID := admin.Casinos[0].ID
+
+	// A dealer of their own, so clocking out does not affect other tests
+	suffix := time.Now().UnixNano()
+	password := "password123"
+	hashedPassword, _ := utils.HashPassword(password)
+	user := models.User{ID: uuid.New(), Name: "Shift Dealer", Email: fmt.Sprintf("shift%d@example.com", suffix), Password: hashedPassword, Role: "dealer", Provider: "local", Verified: true}
+	dealerProfile := models.Dealer{ID: uuid.New(), UserID: user.ID, DealerCode: fmt.Sprintf("S%d", suffix), Status: "active"}
+	game := models.Game{ID: uuid.New(), Name: fmt.Sprintf("Shift Game %d", suffix), Type: "Blackjack", MaxPlayers: 7, MinPlayers: 1, MinBet: 1_00, MaxBet: 1000_00}
+	player := models.Player{ID: uuid.New(), Nickname: fmt.Sprintf("shift-%d", suffix), Status: "active"}
+	for _, record := range []interface{}{&user, &dealerProfile, &game, &player} {
+		if err := testDB.Create(record).Error; err != nil {
+			t.Fatalf("Failed to create %T: %v", record, err)
+		}
+	}
+	if err := testDB.Exec("INSERT INTO casino_dealers (casino_id, dealer_id) VALUES (?, ?)", casinoID, dealerProfile.ID).Error; err != nil {
+		t.Fatalf("Failed to assign dealer: %v", err)
+	}
+	if err := testDB.Exec("INSERT INTO casino_games (casino_id, game_id) VALUES (?, ?)", casinoID, game.ID).Error; err != nil {
+		t.Fatalf("Failed to offer game at casino: %v", err)
+	}
+	dealer := signIn(t, user.Email, password)
+
+	decodeShift := func(w *httptest.ResponseRecorder) models.ShiftResponse {
+		var response struct {
+			Data models.ShiftResponse `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response.Data
+	}
+
+	clockIn := func() *httptest.ResponseRecorder {
+		return authedRequest(dealer.AccessToken, "POST", "/api/shifts/clock-in", models.ClockInRequest{CasinoID: casinoID.String()})
+	}
+
+	var worked models.ShiftResponse
+
+	t.Run("ClockInAndOut", func(t *testing.T) {
+		w := clockIn()
+		assert.Equal(t, http.StatusOK, w.Code)
+		shift := decodeShift(w)
+		assert.Equal(t, models.ShiftStatusActive, shift.Status)
+		assert.NotNil(t, shift.ClockInAt)
+
+		w = clockIn()
+		assert.Equal(t, http.StatusConflict, w.Code, "already clocked in")
+
+		w = authedRequest(dealer.AccessToken, "GET", "/api/shifts/current", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, shift.ID, decodeShift(w).ID)
+
+		w = authedRequest(dealer.AccessToken, "POST", "/api/shifts/clock-out", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		worked = decodeShift(w)
+		assert.Equal(t, models.ShiftStatusCompleted, worked.Status)
+		assert.NotNil(t, worked.ClockOutAt)
+
+		w = authedRequest(dealer.AccessToken, "POST", "/api/shifts/clock-out", nil)
+		assert.Equal(t, http.StatusConflict, w.Code, "not clocked in")
+		w = authedRequest(dealer.AccessToken, "GET", "/api/shifts/current", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Breaks", func(t *testing.T) {
+		w := authedRequest(dealer.AccessToken, "POST", "/api/shifts/breaks/start", nil)
+		assert.Equal(t, http.StatusConflict, w.Code, "not clocked in")
+
+		assert.Equal(t, http.StatusOK, clockIn().Code)
+
+		w = authedRequest(dealer.AccessToken, "POST", "/api/shifts/breaks/start", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, decodeShift(w).OnBreak)
+
+		w = authedRequest(dealer.AccessToken, "POST", "/api/shifts/breaks/start", nil)
+		assert.Equal(t, http.StatusConflict, w.Code, "already on a break")
+
+		w = authedRequest(dealer.AccessToken, "POST", "/api/shifts/breaks/end", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		shift := decodeShift(w)
+		assert.False(t, shift.OnBreak)
+		if assert.Len(t, shift.Breaks, 1) {
+			assert.NotNil(t, shift.Breaks[0].EndTime)
+		}
+
+		w = authedRequest(dealer.AccessToken, "POST", "/api/shifts/breaks/end", nil)
+		assert.Equal(t, http.StatusConflict, w.Code, "not on a break")
+
+		// Clocking out ends a break in progress
+		w = authedRequest(dealer.AccessToken, "POST", "/api/shifts/breaks/start", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		w = authedRequest(dealer.AccessToken, "POST", "/api/shifts/clock-out", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		shift = decodeShift(w)
+		assert.False(t, shift.OnBreak)
+		assert.Len(t, shift.Breaks, 2)
+	})
+
+	// Planned well ahead so clocking in never picks these shifts up
+	day := time.Now().AddDate(0, 1, 0).Truncate(24 * time.Hour)
+	plan := func(start, end int) *httptest.ResponseRecorder {
+		return authedRequest(admin.AccessToken, "POST", "/api/shifts/", models.CreateShiftRequest{
+			DealerID:     dealerProfile.ID.String(),
+			CasinoID:     casinoID.String(),
+			PlannedStart: day.Add(time.Duration(start) * time.Hour),
+			PlannedEnd:   day.Add(time.Duration(end) * time.Hour),
+		})
+	}
+
+	var planned models.ShiftResponse
+
+	t.Run("CreateShiftRejectsOverlap", func(t *testing.T) {
+		w := plan(10, 18)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		planned = decodeShift(w)
+		assert.Equal(t, models.ShiftStatusPlanned, planned.Status)
+
+		w = plan(12, 20)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = plan(18, 22)
+		assert.Equal(t, http.StatusCreated, w.Code, "back to back shifts do not overlap")
+
+		w = plan(22, 20)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("DeleteOnlyPlannedShifts", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "DELETE", "/api/shifts/"+worked.ID.String(), nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = authedRequest(admin.AccessToken, "DELETE", "/api/shifts/"+planned.ID.String(), nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = authedRequest(admin.AccessToken, "DELETE", "/api/shifts/"+planned.ID.String(), nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("SessionNeedsClockedInDealer", func(t *testing.T) {
+		openSession := func() *httptest.ResponseRecorder {
+			return authedRequest(admin.AccessToken, "POST", "/api/game-summaries/", models.CreateGameSummaryRequest{
+				GameID:    game.ID.String(),
+				CasinoID:  casinoID.String(),
+				StartTime: time.Now(),
+				DealerID:  dealerProfile.ID.String(),
+				PlayerIDs: []string{player.ID.String()},
+			})
+		}
+
+		w := openSession()
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		assert.Equal(t, http.StatusOK, clockIn().Code)
+		w = openSession()
+		assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		w = authedRequest(dealer.AccessToken, "POST", "/api/shifts/clock-out", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,127 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestSoftDelete(t *testing.T) {
+	dealer := signIn(t, "user12@example.com", "password12")
+	admin := signInAdmin(t)
+
+	// A session with one winning and one losing transaction, and a player whose
+	// total matches them
+	game := models.Game{
+		ID:         uuid.New(),
+		Name:       fmt.Sprintf("Soft Delete Game %d", time.Now().UnixNano()),
+		Type:       "Poker",
+		MaxPlayers: 8,
+		MinPlayers: 2,
+		MinBet:     10_00,
+		MaxBet:     1000_00,
+	}
+	player := models.Player{
+		ID:            uuid.New(),
+		Nickname:      fmt.Sprintf("soft-delete-%d", time.Now().UnixNano()),
+		TotalWinnings: 150_00,
+		Status:        "active",
+	}
+	gameSummary := models.GameSummary{
+		ID:        uuid.New(),
+		GameID:    game.ID,
+		CasinoID:  admin.Casinos[0].ID,
+		DealerID:  admin.Dealer.ID,
+		Players:   []models.Player{player},
+		StartTime: time.Now(),
+		Status:    models.GameSummaryStatusInProgress,
+	}
+	win := models.Transaction{ID: uuid.New(), GameSummaryID: gameSummary.ID, PlayerID: player.ID, Amount: 200_00, Type: "bet", Outcome: "win"}
+	loss := models.Transaction{ID: uuid.New(), GameSummaryID: gameSummary.ID, PlayerID: player.ID, Amount: -50_00, Type: "bet", Outcome: "loss"}
+	for _, record := range []interface{}{&game, &player, &gameSummary, &win, &loss} {
+		if err := testDB.Create(record).Error; err != nil {
+			t.Fatalf("Failed to create %T: %v", record, err)
+		}
+	}
+
+	winnings := func() models.Money {
+		var stored models.Player
+		testDB.Unscoped().First(&stored, "id = ?", player.ID)
+		return stored.TotalWinnings
+	}
+
+	results := func(w *httptest.ResponseRecorder) int {
+		var response struct {
+			Results int `json:"results"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response.Results
+	}
+
+	t.Run("RestoreTransaction", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "DELETE", "/api/transactions/"+loss.ID.String(), nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, models.Money(200_00), winnings())
+
+		w = authedRequest(admin.AccessToken, "GET", "/api/transactions/"+loss.ID.String(), nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = authedRequest(admin.AccessToken, "GET", "/api/admin/deleted/transactions?game_summary_id="+gameSummary.ID.String(), nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 1, results(w))
+
+		w = authedRequest(admin.AccessToken, "POST", "/api/admin/deleted/transactions/"+loss.ID.String()+"/restore", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, models.Money(150_00), winnings())
+
+		w = authedRequest(admin.AccessToken, "POST", "/api/admin/deleted/transactions/"+loss.ID.String()+"/restore", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("RestoreGameSummary", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "DELETE", "/api/game-summaries/"+gameSummary.ID.String(), nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, models.Money(0), winnings())
+
+		w = authedRequest(admin.AccessToken, "GET", "/api/game-summaries/"+gameSummary.ID.String(), nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		// Its transactions come back with the session, not on their own
+		w = authedRequest(admin.AccessToken, "POST", "/api/admin/deleted/transactions/"+win.ID.String()+"/restore", nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = authedRequest(admin.AccessToken, "POST", "/api/admin/deleted/game-summaries/"+gameSummary.ID.String()+"/restore", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, models.Money(150_00), winnings())
+
+		w = authedRequest(admin.AccessToken, "GET", "/api/transactions/?game_summary_id="+gameSummary.ID.String(), nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 2, results(w))
+	})
+
+	t.Run("RestorePlayer", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "DELETE", "/api/players/"+player.ID.String(), nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = authedRequest(admin.AccessToken, "GET", "/api/players/"+player.ID.String(), nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = authedRequest(admin.AccessToken, "POST", "/api/admin/deleted/players/"+player.ID.String()+"/restore", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = authedRequest(admin.AccessToken, "GET", "/api/players/"+player.ID.String(), nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("AdminOnly", func(t *testing.T) {
+		w := authedRequest(dealer.AccessToken, "GET", "/api/admin/deleted/transactions", nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
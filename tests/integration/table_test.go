@@ -0,0 +1,172 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestTables(t *testing.T) {
+	admin := signInAdmin(t)
+
+	decodeTable := func(w *httptest.ResponseRecorder) models.TableResponse {
+		var response struct {
+			Data models.TableResponse `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response.Data
+	}
+
+	casinoID := admin.Casinos[0].ID
+	tablesPath := "/api/casinos/" + casinoID.String() + "/tables"
+
+	ensureClockedIn(t, admin.AccessToken, casinoID)
+
+	suffix := time.Now().UnixNano()
+	blackjack := models.Game{ID: uuid.New(), Name: fmt.Sprintf("Tables Blackjack %d", suffix), Type: "Blackjack", MaxPlayers: 7, MinPlayers: 1, MinBet: 1_00, MaxBet: 1000_00}
+	roulette := models.Game{ID: uuid.New(), Name: fmt.Sprintf("Tables Roulette %d", suffix), Type: "Roulette", MaxPlayers: 8, MinPlayers: 1, MinBet: 1_00, MaxBet: 1000_00}
+	player := models.Player{ID: uuid.New(), Nickname: fmt.Sprintf("tables-%d", suffix), Status: "active"}
+	for _, record := range []interface{}{&blackjack, &roulette, &player} {
+		if err := testDB.Create(record).Error; err != nil {
+			t.Fatalf("Failed to create %T: %v", record, err)
+		}
+	}
+	for _, game := range []models.Game{blackjack, roulette} {
+		if err := testDB.Exec("INSERT INTO casino_games (casino_id, game_id) VALUES (?, ?)", casinoID, game.ID).Error; err != nil {
+			t.Fatalf("Failed to offer game at casino: %v", err)
+		}
+	}
+
+	tableNumber := fmt.Sprintf("T%d", suffix)
+	var table models.TableResponse
+
+	t.Run("CreateTable", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "POST", tablesPath, models.CreateTableRequest{TableNumber: tableNumber, GameID: blackjack.ID.String()})
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		table = decodeTable(w)
+		assert.Equal(t, models.TableStatusOpen, table.Status)
+		assert.Equal(t, blackjack.ID, table.Game.ID)
+		assert.Nil(t, table.MinBet)
+		assert.Nil(t, table.MaxBet)
+
+		w = authedRequest(admin.AccessToken, "POST", tablesPath, models.CreateTableRequest{TableNumber: tableNumber, GameID: roulette.ID.String()})
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		minBet, maxBet := models.Money(50_00), models.Money(10_00)
+		w = authedRequest(admin.AccessToken, "POST", tablesPath, models.CreateTableRequest{TableNumber: tableNumber + "-X", GameID: blackjack.ID.String(), MinBet: &minBet, MaxBet: &maxBet})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("FindTables", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "GET", tablesPath+"/"+table.ID.String(), nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, tableNumber, decodeTable(w).TableNumber)
+
+		w = authedRequest(admin.AccessToken, "GET", tablesPath+"?limit=100", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data []models.TableResponse `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		found := false
+		for _, listed := range response.Data {
+			found = found || listed.ID == table.ID
+		}
+		assert.True(t, found, "created table is listed")
+	})
+
+	t.Run("UpdateBetLimits", func(t *testing.T) {
+		minBet, maxBet := models.Money(25_00), models.Money(500_00)
+		w := authedRequest(admin.AccessToken, "PUT", tablesPath+"/"+table.ID.String(), models.UpdateTableRequest{MinBet: &minBet, MaxBet: &maxBet})
+		assert.Equal(t, http.StatusOK, w.Code)
+		updated := decodeTable(w)
+		if assert.NotNil(t, updated.MinBet) && assert.NotNil(t, updated.MaxBet) {
+			assert.Equal(t, minBet, *updated.MinBet)
+			assert.Equal(t, maxBet, *updated.MaxBet)
+		}
+
+		// A minimum above the stored maximum is rejected
+		tooHigh := models.Money(600_00)
+		w = authedRequest(admin.AccessToken, "PUT", tablesPath+"/"+table.ID.String(), models.UpdateTableRequest{MinBet: &tooHigh})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = authedRequest(admin.AccessToken, "PUT", tablesPath+"/"+table.ID.String(), models.UpdateTableRequest{MinBet: &minBet, ClearMinBet: true})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("ClearBetLimits", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "PUT", tablesPath+"/"+table.ID.String(), models.UpdateTableRequest{ClearMinBet: true})
+		assert.Equal(t, http.StatusOK, w.Code)
+		updated := decodeTable(w)
+		assert.Nil(t, updated.MinBet)
+		if assert.NotNil(t, updated.MaxBet) {
+			assert.Equal(t, models.Money(500_00), *updated.MaxBet)
+		}
+
+		w = authedRequest(admin.AccessToken, "PUT", tablesPath+"/"+table.ID.String(), models.UpdateTableRequest{ClearMaxBet: true})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Nil(t, decodeTable(w).MaxBet)
+
+		var stored models.Table
+		testDB.First(&stored, "id = ?", table.ID)
+		assert.Nil(t, stored.MinBet)
+		assert.Nil(t, stored.MaxBet)
+	})
+
+	openSession := func(game models.Game) *httptest.ResponseRecorder {
+		return authedRequest(admin.AccessToken, "POST", "/api/game-summaries/", models.CreateGameSummaryRequest{
+			GameID:    game.ID.String(),
+			CasinoID:  casinoID.String(),
+			StartTime: time.Now(),
+			DealerID:  admin.Dealer.ID.String(),
+			PlayerIDs: []string{player.ID.String()},
+			TableID:   table.ID.String(),
+		})
+	}
+
+	t.Run("SessionAtTable", func(t *testing.T) {
+		w := openSession(roulette)
+		assert.Equal(t, http.StatusBadRequest, w.Code, "table is set up for another game")
+
+		w = authedRequest(admin.AccessToken, "PUT", tablesPath+"/"+table.ID.String(), models.UpdateTableRequest{Status: models.TableStatusMaintenance})
+		assert.Equal(t, http.StatusOK, w.Code)
+		w = openSession(blackjack)
+		assert.Equal(t, http.StatusBadRequest, w.Code, "table is not open")
+
+		w = authedRequest(admin.AccessToken, "PUT", tablesPath+"/"+table.ID.String(), models.UpdateTableRequest{Status: models.TableStatusOpen})
+		assert.Equal(t, http.StatusOK, w.Code)
+		w = openSession(blackjack)
+		if assert.Equal(t, http.StatusCreated, w.Code, w.Body.String()) {
+			var response struct {
+				Data models.GameSummaryResponse `json:"data"`
+			}
+			json.Unmarshal(w.Body.Bytes(), &response)
+			if assert.NotNil(t, response.Data.Table) {
+				assert.Equal(t, table.ID, response.Data.Table.ID)
+			}
+		}
+	})
+
+	t.Run("DeleteTable", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "DELETE", tablesPath+"/"+table.ID.String(), nil)
+		assert.Equal(t, http.StatusConflict, w.Code, "table has recorded sessions")
+
+		w = authedRequest(admin.AccessToken, "POST", tablesPath, models.CreateTableRequest{TableNumber: tableNumber + "-U", GameID: roulette.ID.String()})
+		assert.Equal(t, http.StatusCreated, w.Code)
+		unused := decodeTable(w)
+
+		w = authedRequest(admin.AccessToken, "DELETE", tablesPath+"/"+unused.ID.String(), nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		w = authedRequest(admin.AccessToken, "GET", tablesPath+"/"+unused.ID.String(), nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,121 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestTransactionCorrections(t *testing.T) {
+	admin := signInAdmin(t)
+
+	game := models.Game{
+		ID:         uuid.New(),
+		Name:       fmt.Sprintf("Correction Game %d", time.Now().UnixNano()),
+		Type:       "Poker",
+		MaxPlayers: 8,
+		MinPlayers: 2,
+		MinBet:     10_00,
+		MaxBet:     1000_00,
+	}
+	player := models.Player{
+		ID:            uuid.New(),
+		Nickname:      fmt.Sprintf("correction-%d", time.Now().UnixNano()),
+		TotalWinnings: 200_00,
+		Status:        "active",
+	}
+	gameSummary := models.GameSummary{
+		ID:        uuid.New(),
+		GameID:    game.ID,
+		CasinoID:  admin.Casinos[0].ID,
+		DealerID:  admin.Dealer.ID,
+		Players:   []models.Player{player},
+		StartTime: time.Now(),
+		Status:    models.GameSummaryStatusInProgress,
+	}
+	original := models.Transaction{ID: uuid.New(), GameSummaryID: gameSummary.ID, PlayerID: player.ID, Amount: 200_00, Type: "win", Outcome: "win"}
+	for _, record := range []interface{}{&game, &player, &gameSummary, &original} {
+		if err := testDB.Create(record).Error; err != nil {
+			t.Fatalf("Failed to create %T: %v", record, err)
+		}
+	}
+
+	var correction models.CorrectionResponse
+
+	t.Run("ReasonRequired", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "POST", "/api/transactions/"+original.ID.String()+"/corrections", map[string]interface{}{"amount": "150.00", "outcome": "win"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("BooksReversalAndCorrectedEntry", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "POST", "/api/transactions/"+original.ID.String()+"/corrections", models.CorrectTransactionRequest{Amount: 150_00, Outcome: "win", Reason: "Miscounted chips"})
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var response struct {
+			Data models.CorrectionResponse `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		correction = response.Data
+		assert.Equal(t, models.Money(-200_00), correction.Reversal.Amount)
+		assert.Equal(t, &original.ID, correction.Reversal.ReversesID)
+		assert.Equal(t, models.Money(150_00), correction.Corrected.Amount)
+		assert.Equal(t, &original.ID, correction.Corrected.CorrectsID)
+		assert.Equal(t, "Miscounted chips", correction.Corrected.Reason)
+
+		var stored models.Transaction
+		testDB.First(&stored, "id = ?", original.ID)
+		assert.Equal(t, models.Money(200_00), stored.Amount)
+
+		var storedPlayer models.Player
+		testDB.First(&storedPlayer, "id = ?", player.ID)
+		assert.Equal(t, models.Money(150_00), storedPlayer.TotalWinnings)
+
+		var storedSummary models.GameSummary
+		testDB.First(&storedSummary, "id = ?", gameSummary.ID)
+		assert.Equal(t, models.Money(150_00), storedSummary.TotalPot)
+	})
+
+	t.Run("ShowsCorrectionChain", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "GET", "/api/transactions/"+correction.Corrected.ID.String(), nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data models.TransactionResponse `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		if assert.Len(t, response.Data.CorrectionChain, 3) {
+			assert.Equal(t, original.ID, response.Data.CorrectionChain[0].ID)
+			assert.Equal(t, correction.Reversal.ID, response.Data.CorrectionChain[1].ID)
+			assert.Equal(t, correction.Corrected.ID, response.Data.CorrectionChain[2].ID)
+		}
+	})
+
+	t.Run("CorrectsOnlyOnce", func(t *testing.T) {
+		payload := models.CorrectTransactionRequest{Amount: 100_00, Outcome: "win", Reason: "Second thoughts"}
+
+		w := authedRequest(admin.AccessToken, "POST", "/api/transactions/"+original.ID.String()+"/corrections", payload)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = authedRequest(admin.AccessToken, "POST", "/api/transactions/"+correction.Reversal.ID.String()+"/corrections", payload)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("ChainCannotBeDeleted", func(t *testing.T) {
+		w := authedRequest(admin.AccessToken, "DELETE", "/api/transactions/"+original.ID.String(), nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = authedRequest(admin.AccessToken, "DELETE", "/api/transactions/"+correction.Corrected.ID.String(), nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("ImmutableInDatabase", func(t *testing.T) {
+		err := testDB.Exec("UPDATE transactions SET amount = 1 WHERE id = ?", original.ID).Error
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,119 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+	"github.com/suidevv/tableye-api/utils"
+)
+
+func TestJSONDiff(t *testing.T) {
+	before := models.Casino{Name: "Old", Location: "Ghent", Status: "active"}
+	after := models.Casino{Name: "New", Location: "Ghent", Status: "active"}
+
+	changes, err := utils.JSONDiff(before, after)
+	assert.NoError(t, err)
+
+	var fields map[string]utils.FieldChange
+	assert.NoError(t, json.Unmarshal(changes, &fields))
+	assert.Equal(t, map[string]utils.FieldChange{"name": {Old: "Old", New: "New"}}, fields)
+
+	// Creates and deletes list every field on one side only
+	changes, err = utils.JSONDiff(nil, after)
+	assert.NoError(t, err)
+	fields = nil
+	assert.NoError(t, json.Unmarshal(changes, &fields))
+	assert.Equal(t, utils.FieldChange{New: "Ghent"}, fields["location"])
+
+	changes, err = utils.JSONDiff(&before, (*models.Casino)(nil))
+	assert.NoError(t, err)
+	fields = nil
+	assert.NoError(t, json.Unmarshal(changes, &fields))
+	assert.Equal(t, utils.FieldChange{Old: "Ghent"}, fields["location"])
+}
+
+func TestJSONDiffSkipsHiddenFields(t *testing.T) {
+	before := models.User{Name: "Dealer", Password: "old-hash"}
+	after := models.User{Name: "Dealer", Password: "new-hash"}
+
+	changes, err := utils.JSONDiff(before, after)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(changes))
+}
+
+func auditChain(t *testing.T, n int) []models.AuditEvent {
+	actorID := uuid.New()
+	events := make([]models.AuditEvent, n)
+	prevHash := ""
+	for i := range events {
+		events[i] = models.AuditEvent{
+			ID:         uuid.New(),
+			Sequence:   int64(i + 1),
+			ActorID:    &actorID,
+			ActorRole:  "admin",
+			Action:     models.AuditActionUpdate,
+			EntityType: "casino",
+			EntityID:   uuid.New().String(),
+			Changes:    json.RawMessage(`{"name":{"old":"Old","new":"New"},"max_bet":{"old":100,"new":250.5}}`),
+			ClientIP:   "10.0.0.1",
+			RequestID:  uuid.New().String(),
+			PrevHash:   prevHash,
+			CreatedAt:  time.Date(2024, 5, 1, 12, 0, i, 123456000, time.UTC),
+		}
+		hash, err := events[i].ComputeHash()
+		assert.NoError(t, err)
+		events[i].Hash = hash
+		prevHash = hash
+	}
+	return events
+}
+
+func TestAuditEventHashChain(t *testing.T) {
+	events := auditChain(t, 3)
+
+	for i, event := range events {
+		hash, err := event.ComputeHash()
+		assert.NoError(t, err)
+		assert.Equal(t, event.Hash, hash)
+		if i > 0 {
+			assert.Equal(t, events[i-1].Hash, event.PrevHash)
+		}
+	}
+}
+
+func TestAuditEventHashSurvivesStorage(t *testing.T) {
+	event := auditChain(t, 1)[0]
+
+	// Postgres reorders jsonb keys and hands timestamps back in local time
+	event.Changes = json.RawMessage(`{"max_bet": {"new": 250.5, "old": 100}, "name": {"new": "New", "old": "Old"}}`)
+	event.CreatedAt = event.CreatedAt.In(time.FixedZone("CEST", 2*60*60))
+
+	hash, err := event.ComputeHash()
+	assert.NoError(t, err)
+	assert.Equal(t, event.Hash, hash)
+}
+
+func TestAuditEventHashDetectsTampering(t *testing.T) {
+	tamper := map[string]func(e *models.AuditEvent){
+		"changes":  func(e *models.AuditEvent) { e.Changes = json.RawMessage(`{"name":{"old":"Old","new":"Other"}}`) },
+		"actor":    func(e *models.AuditEvent) { e.ActorID = nil },
+		"action":   func(e *models.AuditEvent) { e.Action = models.AuditActionDelete },
+		"entity":   func(e *models.AuditEvent) { e.EntityID = uuid.New().String() },
+		"time":     func(e *models.AuditEvent) { e.CreatedAt = e.CreatedAt.Add(time.Microsecond) },
+		"sequence": func(e *models.AuditEvent) { e.Sequence++ },
+		"link":     func(e *models.AuditEvent) { e.PrevHash = "" },
+	}
+
+	for name, change := range tamper {
+		event := auditChain(t, 2)[1]
+		change(&event)
+
+		hash, err := event.ComputeHash()
+		assert.NoError(t, err)
+		assert.NotEqual(t, event.Hash, hash, name)
+	}
+}
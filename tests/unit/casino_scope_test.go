@@ -0,0 +1,24 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestCasinoScope(t *testing.T) {
+	own := uuid.New()
+	other := uuid.New()
+
+	scope := models.CasinoScope{CasinoIDs: []uuid.UUID{own}}
+	assert.True(t, scope.Allows(own))
+	assert.False(t, scope.Allows(other))
+
+	// Users without any casino link see nothing
+	assert.False(t, models.CasinoScope{}.Allows(own))
+
+	admin := models.CasinoScope{Unrestricted: true}
+	assert.True(t, admin.Allows(other))
+}
@@ -0,0 +1,27 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestDealerRating(t *testing.T) {
+	// Full pace without voids or corrections earns the maximum rating
+	assert.Equal(t, float32(5), models.DealerRating(models.TargetRoundsPerHour, 0, 0))
+
+	// Dealing faster than the target does not raise the rating further
+	assert.Equal(t, float32(5), models.DealerRating(models.TargetRoundsPerHour*2, 0, 0))
+
+	// Half pace loses half of the pace score
+	assert.Equal(t, float32(3.75), models.DealerRating(models.TargetRoundsPerHour/2, 0, 0))
+
+	// Voiding every session loses the reliability score
+	assert.Equal(t, float32(3.75), models.DealerRating(models.TargetRoundsPerHour, 1, 0))
+
+	// Correcting every transaction loses the accuracy score
+	assert.Equal(t, float32(3.75), models.DealerRating(models.TargetRoundsPerHour, 0, 1))
+
+	assert.Equal(t, float32(0), models.DealerRating(0, 1, 1))
+}
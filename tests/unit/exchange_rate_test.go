@@ -0,0 +1,83 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestIsCurrencyCode(t *testing.T) {
+	assert.True(t, models.IsCurrencyCode("EUR"))
+	assert.True(t, models.IsCurrencyCode("USD"))
+
+	assert.False(t, models.IsCurrencyCode("eur"))
+	assert.False(t, models.IsCurrencyCode("EURO"))
+	assert.False(t, models.IsCurrencyCode("E'R"))
+	assert.False(t, models.IsCurrencyCode(""))
+}
+
+func TestConversionRateSQL(t *testing.T) {
+	expression := models.ConversionRateSQL("gs.currency", "gs.start_time", "USD")
+	assert.Contains(t, expression, "WHEN gs.currency = 'USD' THEN 1")
+	assert.Contains(t, expression, "er.currency = 'USD'")
+
+	// The target is inlined, so anything but a currency code is refused
+	assert.Panics(t, func() { models.ConversionRateSQL("gs.currency", "gs.start_time", "USD' OR 1=1 --") })
+}
+
+func TestParseRate(t *testing.T) {
+	valid := map[string]models.Rate{
+		"1":          models.RateOne,
+		"0.92":       92_000_000,
+		"1.08345678": 108_345_678,
+		"+0.5":       models.RateOne / 2,
+	}
+	for text, expected := range valid {
+		rate, err := models.ParseRate(text)
+		assert.NoError(t, err, text)
+		assert.Equal(t, expected, rate, text)
+	}
+
+	for _, text := range []string{"", ".", "abc", "0.123456789", "1,5"} {
+		_, err := models.ParseRate(text)
+		assert.Error(t, err, text)
+	}
+}
+
+func TestRateJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Rate models.Rate `json:"rate"`
+	}{Rate: 92_000_000})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"rate":"0.92"}`, string(data))
+
+	var request struct {
+		Rate models.Rate `json:"rate"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"rate":"1.1"}`), &request))
+	assert.Equal(t, models.Rate(110_000_000), request.Rate)
+
+	// Plain numbers are read from their text, like amounts
+	assert.NoError(t, json.Unmarshal([]byte(`{"rate":0.3}`), &request))
+	assert.Equal(t, models.Rate(30_000_000), request.Rate)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"rate":1e-3}`), &request))
+}
+
+func TestRateScan(t *testing.T) {
+	var rate models.Rate
+
+	assert.NoError(t, rate.Scan([]byte("0.92000000")))
+	assert.Equal(t, models.Rate(92_000_000), rate)
+	assert.Equal(t, "0.92", rate.String())
+
+	assert.NoError(t, rate.Scan(int64(2)))
+	assert.Equal(t, 2*models.RateOne, rate)
+	assert.Equal(t, "2", rate.String())
+
+	value, err := models.Rate(108_345_678).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.08345678", value)
+}
@@ -0,0 +1,61 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestGamePlayerCount(t *testing.T) {
+	game := models.Game{MinPlayers: 2, MaxPlayers: 6}
+
+	assert.Nil(t, game.CheckPlayerCount(2))
+	assert.Nil(t, game.CheckPlayerCount(6))
+
+	violation := game.CheckPlayerCount(1)
+	assert.NotNil(t, violation)
+	assert.Equal(t, models.RuleMinPlayers, violation.Rule)
+	assert.Equal(t, float64(2), *violation.Limit)
+
+	violation = game.CheckPlayerCount(7)
+	assert.NotNil(t, violation)
+	assert.Equal(t, models.RuleMaxPlayers, violation.Rule)
+}
+
+func TestBetLimits(t *testing.T) {
+	game := models.Game{MinBet: 10_00, MaxBet: 1000_00}
+
+	minBet, maxBet := game.BetLimits(nil)
+	assert.Equal(t, models.Money(10_00), minBet)
+	assert.Equal(t, models.Money(1000_00), maxBet)
+
+	// Table overrides replace the game's limits one by one
+	tableMax := models.Money(500_00)
+	minBet, maxBet = game.BetLimits(&models.Table{MaxBet: &tableMax})
+	assert.Equal(t, models.Money(10_00), minBet)
+	assert.Equal(t, models.Money(500_00), maxBet)
+}
+
+func TestCheckBetAmount(t *testing.T) {
+	assert.Nil(t, models.CheckBetAmount(100_00, 10_00, 1000_00))
+
+	// Losses are negative, the limits apply to the absolute amount
+	assert.Nil(t, models.CheckBetAmount(-100_00, 10_00, 1000_00))
+
+	violation := models.CheckBetAmount(-5_00, 10_00, 1000_00)
+	assert.NotNil(t, violation)
+	assert.Equal(t, models.RuleMinBet, violation.Rule)
+	assert.Equal(t, models.Money(10_00), *violation.BetLimit)
+
+	violation = models.CheckBetAmount(1500_00, 10_00, 1000_00)
+	assert.NotNil(t, violation)
+	assert.Equal(t, models.RuleMaxBet, violation.Rule)
+	assert.Equal(t, models.Money(1000_00), *violation.BetLimit)
+
+	// One cent over the limit is already too much
+	assert.NotNil(t, models.CheckBetAmount(1000_01, 10_00, 1000_00))
+
+	// No upper limit when the maximum is zero
+	assert.Nil(t, models.CheckBetAmount(1_000_000_00, 10_00, 0))
+}
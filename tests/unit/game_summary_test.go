@@ -0,0 +1,34 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestGameSummaryTransitions(t *testing.T) {
+	summary := models.GameSummary{Status: models.GameSummaryStatusScheduled}
+	assert.True(t, summary.CanTransitionTo(models.GameSummaryStatusInProgress))
+	assert.True(t, summary.CanTransitionTo(models.GameSummaryStatusVoided))
+	assert.False(t, summary.CanTransitionTo(models.GameSummaryStatusPaused))
+	assert.False(t, summary.CanTransitionTo(models.GameSummaryStatusCompleted))
+
+	summary.Status = models.GameSummaryStatusInProgress
+	assert.True(t, summary.CanTransitionTo(models.GameSummaryStatusPaused))
+	assert.True(t, summary.CanTransitionTo(models.GameSummaryStatusCompleted))
+	assert.False(t, summary.CanTransitionTo(models.GameSummaryStatusScheduled))
+
+	summary.Status = models.GameSummaryStatusPaused
+	assert.True(t, summary.CanTransitionTo(models.GameSummaryStatusInProgress))
+	assert.True(t, summary.CanTransitionTo(models.GameSummaryStatusCompleted))
+
+	summary.Status = models.GameSummaryStatusCompleted
+	assert.True(t, summary.IsClosed())
+	assert.False(t, summary.CanTransitionTo(models.GameSummaryStatusInProgress))
+	assert.False(t, summary.CanTransitionTo(models.GameSummaryStatusVoided))
+
+	summary.Status = models.GameSummaryStatusVoided
+	assert.True(t, summary.IsClosed())
+	assert.False(t, summary.CanTransitionTo(models.GameSummaryStatusInProgress))
+}
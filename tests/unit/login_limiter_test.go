@@ -0,0 +1,84 @@
+package unit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/utils"
+)
+
+func TestLoginPolicyDelay(t *testing.T) {
+	policy := utils.LoginPolicy{FreeAttempts: 3, BaseDelay: time.Second, MaxDelay: 10 * time.Second, Window: time.Hour}
+
+	assert.Equal(t, time.Duration(0), policy.Delay(3))
+	assert.Equal(t, time.Second, policy.Delay(4))
+	assert.Equal(t, 2*time.Second, policy.Delay(5))
+	assert.Equal(t, 8*time.Second, policy.Delay(7))
+	assert.Equal(t, 10*time.Second, policy.Delay(8))
+	assert.Equal(t, 10*time.Second, policy.Delay(100))
+}
+
+func TestMemoryLoginLimiter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := utils.NewMemoryLoginLimiter(utils.LoginPolicy{FreeAttempts: 2, BaseDelay: time.Minute, MaxDelay: time.Hour, Window: 30 * time.Minute})
+	limiter.SetClock(func() time.Time { return now })
+
+	fail := func(key string) time.Duration {
+		delay, err := limiter.Fail(key)
+		assert.NoError(t, err)
+		return delay
+	}
+	blocked := func(key string) time.Duration {
+		wait, err := limiter.Blocked(key)
+		assert.NoError(t, err)
+		return wait
+	}
+
+	t.Run("Blocks after the free attempts with growing delays", func(t *testing.T) {
+		assert.Zero(t, fail("a"))
+		assert.Zero(t, fail("a"))
+		assert.Zero(t, blocked("a"))
+
+		assert.Equal(t, time.Minute, fail("a"))
+		assert.Equal(t, time.Minute, blocked("a"))
+		assert.Zero(t, blocked("b"))
+
+		now = now.Add(time.Minute)
+		assert.Zero(t, blocked("a"))
+		assert.Equal(t, 2*time.Minute, fail("a"))
+	})
+
+	t.Run("Reset clears the attempts", func(t *testing.T) {
+		assert.NoError(t, limiter.Reset("a"))
+		assert.Zero(t, blocked("a"))
+		assert.Zero(t, fail("a"))
+	})
+
+	t.Run("Failures expire after the window", func(t *testing.T) {
+		fail("c")
+		fail("c")
+		now = now.Add(31 * time.Minute)
+		assert.Zero(t, fail("c"))
+		assert.Zero(t, fail("c"))
+		assert.Equal(t, time.Minute, fail("c"))
+	})
+
+	t.Run("Expired keys are swept", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			fail(fmt.Sprintf("spray-%d@example.com", i))
+		}
+		assert.GreaterOrEqual(t, limiter.Len(), 100)
+
+		// Eight failures block "e" for 32 minutes, beyond the window
+		for i := 0; i < 8; i++ {
+			fail("e")
+		}
+
+		now = now.Add(31 * time.Minute)
+		fail("d")
+		assert.Equal(t, 2, limiter.Len())
+		assert.NotZero(t, blocked("e"))
+	})
+}
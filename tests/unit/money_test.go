@@ -0,0 +1,80 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestParseMoney(t *testing.T) {
+	valid := map[string]models.Money{
+		"12":      12_00,
+		"-0.5":    -50,
+		"1500.25": 1500_25,
+		".75":     75,
+		"+3.10":   3_10,
+	}
+	for text, expected := range valid {
+		amount, err := models.ParseMoney(text)
+		assert.NoError(t, err, text)
+		assert.Equal(t, expected, amount, text)
+	}
+
+	for _, text := range []string{"", "-", ".", "abc", "1.234", "1,50", "1.2.3", "99999999999999999999"} {
+		_, err := models.ParseMoney(text)
+		assert.Error(t, err, text)
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	assert.Equal(t, "0.00", models.Money(0).String())
+	assert.Equal(t, "12.50", models.Money(12_50).String())
+	assert.Equal(t, "-0.05", models.Money(-5).String())
+	assert.Equal(t, "-1500.25", models.Money(-1500_25).String())
+}
+
+func TestMoneyJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Amount models.Money `json:"amount"`
+	}{Amount: 10_10})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount":"10.10"}`, string(data))
+
+	var request struct {
+		Amount models.Money `json:"amount"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount":"0.10"}`), &request))
+	assert.Equal(t, models.Money(10), request.Amount)
+
+	// Plain numbers are read from their text, so 0.1 + 0.2 stays exact
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount":0.3}`), &request))
+	assert.Equal(t, models.Money(30), request.Amount)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"amount":1e3}`), &request))
+	assert.Error(t, json.Unmarshal([]byte(`{"amount":"1.005"}`), &request))
+}
+
+func TestMoneyScan(t *testing.T) {
+	var amount models.Money
+
+	assert.NoError(t, amount.Scan([]byte("33.335")))
+	assert.Equal(t, models.Money(33_34), amount)
+
+	assert.NoError(t, amount.Scan("-33.335"))
+	assert.Equal(t, models.Money(-33_34), amount)
+
+	assert.NoError(t, amount.Scan(int64(7)))
+	assert.Equal(t, models.Money(7_00), amount)
+
+	assert.NoError(t, amount.Scan(0.29))
+	assert.Equal(t, models.Money(29), amount)
+
+	assert.NoError(t, amount.Scan(nil))
+	assert.Equal(t, models.Money(0), amount)
+
+	value, err := models.Money(-12_50).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "-12.50", value)
+}
@@ -0,0 +1,35 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/models"
+)
+
+func TestDefaultRolePermissions(t *testing.T) {
+	catalogue := make(map[string]bool)
+	for _, permission := range models.Permissions {
+		assert.False(t, catalogue[permission.Name], "Duplicate permission %s", permission.Name)
+		catalogue[permission.Name] = true
+	}
+
+	for role, permissions := range models.DefaultRolePermissions {
+		assert.True(t, models.IsValidRole(role), "Unknown role %s", role)
+		for _, permission := range permissions {
+			assert.True(t, catalogue[permission], "Role %s is granted unknown permission %s", role, permission)
+		}
+	}
+
+	// Admins start with every permission
+	assert.ElementsMatch(t, func() []string {
+		names := make([]string, len(models.Permissions))
+		for i, permission := range models.Permissions {
+			names[i] = permission.Name
+		}
+		return names
+	}(), models.DefaultRolePermissions[models.RoleAdmin])
+
+	assert.NotContains(t, models.DefaultRolePermissions[models.RoleDealer], models.PermissionReportsRead)
+	assert.Contains(t, models.DefaultRolePermissions[models.RoleCasinoOwner], models.PermissionReportsRead)
+}
@@ -0,0 +1,77 @@
+package unit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suidevv/tableye-api/utils"
+)
+
+// Base32 of the RFC 6238 SHA-1 test key "12345678901234567890"
+const rfcTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	// The RFC vectors are eight digits; the API uses the last six
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, expected := range vectors {
+		code, err := utils.TOTPCode(rfcTOTPSecret, utils.TOTPStep(time.Unix(unix, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, code, "code at %d", unix)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	step := utils.TOTPStep(now)
+
+	current, _ := utils.TOTPCode(rfcTOTPSecret, step)
+	matched, ok := utils.ValidateTOTP(rfcTOTPSecret, current, now)
+	assert.True(t, ok)
+	assert.Equal(t, step, matched)
+
+	// One step of drift either way is allowed
+	previous, _ := utils.TOTPCode(rfcTOTPSecret, step-1)
+	matched, ok = utils.ValidateTOTP(rfcTOTPSecret, previous, now)
+	assert.True(t, ok)
+	assert.Equal(t, step-1, matched)
+
+	stale, _ := utils.TOTPCode(rfcTOTPSecret, step-2)
+	_, ok = utils.ValidateTOTP(rfcTOTPSecret, stale, now)
+	assert.False(t, ok)
+
+	_, ok = utils.ValidateTOTP(rfcTOTPSecret, "12345", now)
+	assert.False(t, ok)
+}
+
+func TestTOTPSecretAndProvisioningURI(t *testing.T) {
+	secret, err := utils.GenerateTOTPSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	uri := utils.TOTPProvisioningURI("Tableye", "admin@example.com", secret)
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Tableye:admin@example.com?"))
+	assert.Contains(t, uri, "secret="+secret)
+	assert.Contains(t, uri, "issuer=Tableye")
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := utils.GenerateRecoveryCodes(10)
+	assert.NoError(t, err)
+	assert.Len(t, codes, 10)
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, code)
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+
+	assert.Equal(t, codes[0], utils.NormalizeRecoveryCode(" "+strings.ToUpper(strings.ReplaceAll(codes[0], "-", ""))+" "))
+}
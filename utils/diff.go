@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// FieldChange is the old and new JSON value of one field.
+type FieldChange struct {
+	Old interface{} `json:"old,omitempty"`
+	New interface{} `json:"new,omitempty"`
+}
+
+// JSONDiff compares the JSON forms of before and after and returns the fields
+// that differ. Either side may be nil, for creates and deletes. Fields hidden
+// from JSON, such as password hashes, never appear.
+func JSONDiff(before, after interface{}) (json.RawMessage, error) {
+	oldFields, err := jsonFields(before)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := jsonFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]FieldChange)
+	for name, oldValue := range oldFields {
+		if newValue, ok := newFields[name]; !ok || !reflect.DeepEqual(oldValue, newValue) {
+			changes[name] = FieldChange{Old: oldValue, New: newFields[name]}
+		}
+	}
+	for name, newValue := range newFields {
+		if _, ok := oldFields[name]; !ok {
+			changes[name] = FieldChange{New: newValue}
+		}
+	}
+
+	return json.Marshal(changes)
+}
+
+func jsonFields(value interface{}) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+	if value == nil || (reflect.ValueOf(value).Kind() == reflect.Ptr && reflect.ValueOf(value).IsNil()) {
+		return fields, nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
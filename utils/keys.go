@@ -0,0 +1,234 @@
+package utils
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// KeyRing signs tokens with one RSA key and verifies them with any of its
+// public keys, looked up by the "kid" header. Keeping retired public keys on
+// the ring lets tokens signed before a key rotation stay valid until they
+// expire.
+type KeyRing struct {
+	signingKeyID string
+	signingKey   *rsa.PrivateKey
+	publicKeys   map[string]*rsa.PublicKey
+}
+
+// KeySource loads a key ring. Implementations read keys from the
+// environment, from files or from a directory.
+type KeySource interface {
+	KeyRing() (*KeyRing, error)
+}
+
+// NewKeyRing builds a key ring from a base64-encoded PEM private key and any
+// number of base64-encoded PEM public keys that are only used to verify.
+func NewKeyRing(privateKey string, verifyOnlyKeys ...string) (*KeyRing, error) {
+	decodedPrivateKey, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode key: %w", err)
+	}
+
+	var decodedPublicKeys [][]byte
+	for _, publicKey := range verifyOnlyKeys {
+		if publicKey == "" {
+			continue
+		}
+		decodedPublicKey, err := base64.StdEncoding.DecodeString(publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode: %w", err)
+		}
+		decodedPublicKeys = append(decodedPublicKeys, decodedPublicKey)
+	}
+	return NewKeyRingFromPEM(decodedPrivateKey, decodedPublicKeys...)
+}
+
+// NewKeyRingFromPEM builds a key ring from a PEM private key and PEM public
+// keys that are only used to verify.
+func NewKeyRingFromPEM(privateKey []byte, verifyOnlyKeys ...[]byte) (*KeyRing, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %w", err)
+	}
+
+	ring := &KeyRing{
+		signingKeyID: KeyID(&key.PublicKey),
+		signingKey:   key,
+		publicKeys:   map[string]*rsa.PublicKey{},
+	}
+	ring.publicKeys[ring.signingKeyID] = &key.PublicKey
+
+	for _, publicKey := range verifyOnlyKeys {
+		parsed, err := jwt.ParseRSAPublicKeyFromPEM(publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("parse public key: %w", err)
+		}
+		ring.publicKeys[KeyID(parsed)] = parsed
+	}
+	return ring, nil
+}
+
+// SigningKeyID is the "kid" of the key new tokens are signed with.
+func (r *KeyRing) SigningKeyID() string {
+	return r.signingKeyID
+}
+
+// JSONWebKey is the RFC 7517 representation of an RSA public key.
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWKS returns every public key on the ring, the signing key first.
+func (r *KeyRing) JWKS() JSONWebKeySet {
+	kids := make([]string, 0, len(r.publicKeys))
+	for kid := range r.publicKeys {
+		if kid != r.signingKeyID {
+			kids = append(kids, kid)
+		}
+	}
+	sort.Strings(kids)
+	kids = append([]string{r.signingKeyID}, kids...)
+
+	set := JSONWebKeySet{Keys: make([]JSONWebKey, len(kids))}
+	for i, kid := range kids {
+		key := r.publicKeys[kid]
+		set.Keys[i] = JSONWebKey{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: "RS256",
+			Kid: kid,
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+	}
+	return set
+}
+
+// KeyID is the RFC 7638 JWK thumbprint of key, used as its "kid".
+func KeyID(key *rsa.PublicKey) string {
+	thumbprint, _ := json.Marshal(struct {
+		E   string `json:"e"`
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+	}{
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		Kty: "RSA",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+	})
+	sum := sha256.Sum256(thumbprint)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// SplitKeys splits a comma-separated list of keys or paths.
+func SplitKeys(keys string) []string {
+	var result []string
+	for _, key := range strings.Split(keys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
+// EnvKeys holds base64-encoded PEM keys, as set in app.env.
+type EnvKeys struct {
+	PrivateKey string
+	PublicKeys []string
+}
+
+func (k EnvKeys) KeyRing() (*KeyRing, error) {
+	return NewKeyRing(k.PrivateKey, k.PublicKeys...)
+}
+
+// KeyFiles reads a PEM private key and verify-only PEM public keys from disk.
+type KeyFiles struct {
+	PrivateKeyFile string
+	PublicKeyFiles []string
+}
+
+func (k KeyFiles) KeyRing() (*KeyRing, error) {
+	privateKey, err := os.ReadFile(k.PrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("read private key: %w", err)
+	}
+
+	publicKeys := make([][]byte, len(k.PublicKeyFiles))
+	for i, file := range k.PublicKeyFiles {
+		if publicKeys[i], err = os.ReadFile(file); err != nil {
+			return nil, fmt.Errorf("read public key: %w", err)
+		}
+	}
+	return NewKeyRingFromPEM(privateKey, publicKeys...)
+}
+
+// KeyDirectory reads every *.pem file in Dir. The private key whose file
+// name sorts last signs new tokens, so naming keys by date makes the newest
+// one active. All other keys, private or public, only verify. Dropping a new
+// key into the directory, removing the oldest one and restarting rotates keys
+// without invalidating tokens in flight.
+type KeyDirectory struct {
+	Dir string
+}
+
+func (k KeyDirectory) KeyRing() (*KeyRing, error) {
+	files, err := filepath.Glob(filepath.Join(k.Dir, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("list keys: %w", err)
+	}
+	sort.Strings(files)
+
+	var privateKeys, publicKeys [][]byte
+	for _, file := range files {
+		contents, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read key: %w", err)
+		}
+
+		block, _ := pem.Decode(contents)
+		if block == nil {
+			return nil, fmt.Errorf("read key: %s is not PEM encoded", filepath.Base(file))
+		}
+		if strings.Contains(block.Type, "PRIVATE KEY") {
+			privateKeys = append(privateKeys, contents)
+		} else {
+			publicKeys = append(publicKeys, contents)
+		}
+	}
+
+	if len(privateKeys) == 0 {
+		return nil, fmt.Errorf("no private key in %s", k.Dir)
+	}
+
+	ring, err := NewKeyRingFromPEM(privateKeys[len(privateKeys)-1], publicKeys...)
+	if err != nil {
+		return nil, err
+	}
+	for _, privateKey := range privateKeys[:len(privateKeys)-1] {
+		key, err := jwt.ParseRSAPrivateKeyFromPEM(privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("parse private key: %w", err)
+		}
+		ring.publicKeys[KeyID(&key.PublicKey)] = &key.PublicKey
+	}
+	return ring, nil
+}
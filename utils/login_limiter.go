@@ -0,0 +1,162 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// LoginLimiter tracks failed sign-in attempts per key, such as an email
+// address or client IP. Implementations backed by a shared store let several
+// API instances enforce the same limits.
+type LoginLimiter interface {
+	// Blocked returns how long key must wait before it may try again, or zero.
+	Blocked(key string) (time.Duration, error)
+	// Fail records a failed attempt for key and returns the block it caused,
+	// or zero while key is still within its free attempts.
+	Fail(key string) (time.Duration, error)
+	// Reset forgets every failed attempt of key.
+	Reset(key string) error
+}
+
+// LoginPolicy decides when failed attempts start blocking a key. After
+// FreeAttempts failures every further failure blocks the key for BaseDelay,
+// doubling each time up to MaxDelay. Failures are forgotten once Window has
+// passed without a new one.
+type LoginPolicy struct {
+	FreeAttempts int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	Window       time.Duration
+}
+
+// Delay returns how long a key is blocked after its nth failure.
+func (p LoginPolicy) Delay(failures int) time.Duration {
+	if failures <= p.FreeAttempts {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := p.FreeAttempts + 1; i < failures; i++ {
+		delay *= 2
+		if delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	if delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+type loginAttempts struct {
+	failures     int
+	lastFailure  time.Time
+	blockedUntil time.Time
+}
+
+// MemoryLoginLimiter keeps failed attempts in process memory. Counts are lost
+// on restart and are not shared between instances. Expired keys are swept
+// once per Window, so keys seen only once do not pile up.
+type MemoryLoginLimiter struct {
+	policy    LoginPolicy
+	now       func() time.Time
+	mu        sync.Mutex
+	attempts  map[string]*loginAttempts
+	lastSweep time.Time
+}
+
+func NewMemoryLoginLimiter(policy LoginPolicy) *MemoryLoginLimiter {
+	return &MemoryLoginLimiter{policy: policy, now: time.Now, attempts: make(map[string]*loginAttempts), lastSweep: time.Now()}
+}
+
+// SetClock replaces the limiter's clock, which tests use to move time forward.
+func (l *MemoryLoginLimiter) SetClock(now func() time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.now = now
+	l.lastSweep = now()
+}
+
+// Len returns how many keys have failed attempts on record.
+func (l *MemoryLoginLimiter) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.attempts)
+}
+
+func (l *MemoryLoginLimiter) Blocked(key string) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	attempts := l.current(key)
+	if attempts == nil {
+		return 0, nil
+	}
+	if wait := attempts.blockedUntil.Sub(l.now()); wait > 0 {
+		return wait, nil
+	}
+	return 0, nil
+}
+
+func (l *MemoryLoginLimiter) Fail(key string) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) >= l.policy.Window {
+		l.sweep()
+		l.lastSweep = now
+	}
+
+	attempts := l.current(key)
+	if attempts == nil {
+		attempts = &loginAttempts{}
+		l.attempts[key] = attempts
+	}
+
+	attempts.failures++
+	attempts.lastFailure = now
+	delay := l.policy.Delay(attempts.failures)
+	if delay > 0 {
+		attempts.blockedUntil = now.Add(delay)
+	}
+	return delay, nil
+}
+
+func (l *MemoryLoginLimiter) Reset(key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.attempts, key)
+	return nil
+}
+
+// current returns the attempts of key, dropping them once they have expired.
+// The caller must hold l.mu.
+func (l *MemoryLoginLimiter) current(key string) *loginAttempts {
+	attempts, ok := l.attempts[key]
+	if !ok {
+		return nil
+	}
+
+	if l.live(attempts) {
+		return attempts
+	}
+	delete(l.attempts, key)
+	return nil
+}
+
+// live reports whether attempts still block or count. The caller must hold l.mu.
+func (l *MemoryLoginLimiter) live(attempts *loginAttempts) bool {
+	now := l.now()
+	return now.Before(attempts.blockedUntil) || now.Sub(attempts.lastFailure) < l.policy.Window
+}
+
+// sweep drops every expired key. The caller must hold l.mu.
+func (l *MemoryLoginLimiter) sweep() {
+	for key, attempts := range l.attempts {
+		if !l.live(attempts) {
+			delete(l.attempts, key)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"fmt"
+	"log"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+)
+
+// Mail is a plain-text email.
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers mail to users.
+type Mailer interface {
+	Send(mail Mail) error
+}
+
+// SMTPMailer sends mail through an SMTP server. Username may be empty for
+// relays that do not authenticate.
+type SMTPMailer struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+func (m SMTPMailer) Send(mail Mail) error {
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+
+	message := strings.Join([]string{
+		"From: " + m.From,
+		"To: " + mail.To,
+		"Subject: " + mail.Subject,
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		mail.Body,
+	}, "\r\n")
+
+	if err := smtp.SendMail(fmt.Sprintf("%s:%d", m.Host, m.Port), auth, m.From, []string{mail.To}, []byte(message)); err != nil {
+		return fmt.Errorf("send mail: %w", err)
+	}
+	return nil
+}
+
+// LogMailer writes mail to a file, or to the log when Path is empty, instead
+// of sending it. It is meant for local development and tests.
+type LogMailer struct {
+	Path string
+}
+
+func (m LogMailer) Send(mail Mail) error {
+	entry := fmt.Sprintf("To: %s\nSubject: %s\n\n%s\n\n", mail.To, mail.Subject, mail.Body)
+	if m.Path == "" {
+		log.Print("📧 " + entry)
+		return nil
+	}
+
+	file, err := os.OpenFile(m.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("open mail log: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(entry); err != nil {
+		return fmt.Errorf("write mail log: %w", err)
+	}
+	return nil
+}
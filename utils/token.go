@@ -1,56 +1,147 @@
 package utils
 
 import (
-	"encoding/base64"
 	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
 )
 
-func CreateToken(ttl time.Duration, payload interface{}, privateKey string) (string, error) {
-	decodedPrivateKey, err := base64.StdEncoding.DecodeString(privateKey)
-	if err != nil {
-		return "", fmt.Errorf("could not decode key: %w", err)
-	}
-	key, err := jwt.ParseRSAPrivateKeyFromPEM(decodedPrivateKey)
-	if err != nil {
-		return "", fmt.Errorf("create: parse key: %w", err)
+// Token types, carried in the "typ" claim so an access token can never be
+// used as a refresh token or the other way round. A two-factor challenge
+// token proves the password was right and is only good for the second step.
+const (
+	TokenTypeAccess             = "access"
+	TokenTypeRefresh            = "refresh"
+	TokenTypeTwoFactorChallenge = "2fa_challenge"
+)
+
+// TokenClaims are the claims of every token issued by TokenService.
+// CasinoIDs is a snapshot of the user's casino scope when the token was
+// issued and is empty for users that may see every casino.
+type TokenClaims struct {
+	Subject   string
+	Role      string
+	CasinoIDs []string
+	SessionID string
+	TokenID   string
+	Type      string
+	ExpiresAt time.Time
+}
+
+// TokenService issues and validates the API's access and refresh tokens.
+type TokenService struct {
+	keys map[string]*KeyRing
+}
+
+func NewTokenService(accessKeys, refreshKeys *KeyRing) *TokenService {
+	return &TokenService{keys: map[string]*KeyRing{
+		TokenTypeAccess:             accessKeys,
+		TokenTypeRefresh:            refreshKeys,
+		TokenTypeTwoFactorChallenge: accessKeys,
+	}}
+}
+
+// AccessTokenJWKS returns the public keys that verify access tokens.
+func (s *TokenService) AccessTokenJWKS() JSONWebKeySet {
+	return s.keys[TokenTypeAccess].JWKS()
+}
+
+// CreateToken signs claims as a token of claims.Type valid for ttl. A token
+// ID is generated when claims.TokenID is empty. The returned claims are the
+// ones that were signed.
+func (s *TokenService) CreateToken(claims TokenClaims, ttl time.Duration) (string, TokenClaims, error) {
+	ring, ok := s.keys[claims.Type]
+	if !ok {
+		return "", claims, fmt.Errorf("create: unknown token type %q", claims.Type)
 	}
+
 	now := time.Now().UTC()
-	claims := make(jwt.MapClaims)
-	claims["sub"] = payload
-	claims["exp"] = now.Add(ttl).Unix()
-	claims["iat"] = now.Unix()
-	claims["nbf"] = now.Unix()
-	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
-	if err != nil {
-		return "", fmt.Errorf("create: sign token: %w", err)
+	if claims.TokenID == "" {
+		claims.TokenID = uuid.New().String()
 	}
-	return token, nil
-}
+	claims.ExpiresAt = now.Add(ttl)
 
-func ValidateToken(token string, publicKey string) (interface{}, error) {
-	decodedPublicKey, err := base64.StdEncoding.DecodeString(publicKey)
-	if err != nil {
-		return nil, fmt.Errorf("could not decode: %w", err)
+	mapClaims := jwt.MapClaims{
+		"sub": claims.Subject,
+		"typ": claims.Type,
+		"jti": claims.TokenID,
+		"exp": claims.ExpiresAt.Unix(),
+		"iat": now.Unix(),
+		"nbf": now.Unix(),
 	}
-	key, err := jwt.ParseRSAPublicKeyFromPEM(decodedPublicKey)
+	if claims.Role != "" {
+		mapClaims["role"] = claims.Role
+	}
+	if claims.CasinoIDs != nil {
+		mapClaims["casino_ids"] = claims.CasinoIDs
+	}
+	if claims.SessionID != "" {
+		mapClaims["sid"] = claims.SessionID
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, mapClaims)
+	token.Header["kid"] = ring.signingKeyID
+	signed, err := token.SignedString(ring.signingKey)
 	if err != nil {
-		return "", fmt.Errorf("validate: parse key: %w", err)
+		return "", claims, fmt.Errorf("create: sign token: %w", err)
 	}
+	return signed, claims, nil
+}
+
+// ValidateToken checks the signature, lifetime and type of token.
+func (s *TokenService) ValidateToken(tokenType, token string) (TokenClaims, error) {
+	ring, ok := s.keys[tokenType]
+	if !ok {
+		return TokenClaims{}, fmt.Errorf("validate: unknown token type %q", tokenType)
+	}
+
 	parsedToken, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodRSA); !ok {
 			return nil, fmt.Errorf("unexpected method: %s", t.Header["alg"])
 		}
+		kid, _ := t.Header["kid"].(string)
+		if kid == "" {
+			kid = ring.signingKeyID
+		}
+		key, ok := ring.publicKeys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key %q", kid)
+		}
 		return key, nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("validate: %w", err)
+		return TokenClaims{}, fmt.Errorf("validate: %w", err)
 	}
-	claims, ok := parsedToken.Claims.(jwt.MapClaims)
+	mapClaims, ok := parsedToken.Claims.(jwt.MapClaims)
 	if !ok || !parsedToken.Valid {
-		return nil, fmt.Errorf("validate: invalid token")
+		return TokenClaims{}, fmt.Errorf("validate: invalid token")
+	}
+
+	claims := TokenClaims{}
+	claims.Subject, _ = mapClaims["sub"].(string)
+	claims.Role, _ = mapClaims["role"].(string)
+	claims.SessionID, _ = mapClaims["sid"].(string)
+	claims.TokenID, _ = mapClaims["jti"].(string)
+	claims.Type, _ = mapClaims["typ"].(string)
+	if exp, ok := mapClaims["exp"].(float64); ok {
+		claims.ExpiresAt = time.Unix(int64(exp), 0).UTC()
+	}
+	if casinoIDs, ok := mapClaims["casino_ids"].([]interface{}); ok {
+		claims.CasinoIDs = make([]string, 0, len(casinoIDs))
+		for _, id := range casinoIDs {
+			if s, ok := id.(string); ok {
+				claims.CasinoIDs = append(claims.CasinoIDs, s)
+			}
+		}
+	}
+
+	if claims.Type != tokenType {
+		return TokenClaims{}, fmt.Errorf("validate: expected %s token", tokenType)
+	}
+	if claims.Subject == "" || claims.TokenID == "" {
+		return TokenClaims{}, fmt.Errorf("validate: missing token claims")
 	}
-	return claims["sub"], nil
+	return claims, nil
 }